@@ -0,0 +1,237 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"log"
+	"sort"
+	"strings"
+)
+
+const (
+	// deprecatedAnnotation marks constants which should no longer be used,
+	// its value may explain why.
+	deprecatedAnnotation = "deprecated"
+	// aliasAnnotation lists former names of a constant, separated by commas.
+	aliasAnnotation = "alias"
+)
+
+// isDeprecated reports whether annotations mark a constant as deprecated.
+func isDeprecated(annotations map[string]string) bool {
+	v, ok := annotations[deprecatedAnnotation]
+	return ok && v != "false"
+}
+
+// explicitAliases returns the names listed in the alias annotation of v.
+func explicitAliases(v Value) []string {
+	raw, ok := v.annotations[aliasAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var aliases []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !token.IsIdentifier(name) {
+			log.Fatalf("alias %q of %s is not a valid name", name, v.originalName)
+		}
+		aliases = append(aliases, name)
+	}
+
+	return aliases
+}
+
+// canonical returns the constant whose name String() returns among constants
+// sharing a value, which is the first one not deprecated, or the first one if
+// all of them are.
+func canonical(same []Value) Value {
+	for _, v := range same {
+		if !isDeprecated(v.annotations) {
+			return v
+		}
+	}
+	return same[0]
+}
+
+// valueNames holds every name of a single value of a type.
+type valueNames struct {
+	// The constant whose name String() returns
+	canonical Value
+	// The other constants with the same value, in the order of declaration
+	others []Value
+	// The names given by alias annotations
+	aliases []string
+	// Whether all the constants with the value are deprecated
+	deprecated bool
+}
+
+// names returns the names other than the canonical one.
+func (n valueNames) names() []string {
+	var names []string
+	for _, v := range n.others {
+		names = append(names, v.name)
+	}
+	return append(names, n.aliases...)
+}
+
+// namesByValue groups the values by value, in increasing order. It exits if
+// an alias is the name of a constant with a different value.
+func namesByValue(typeName string, values []Value) []valueNames {
+	sorted := make([]Value, len(values))
+	copy(sorted, values)
+	sort.Stable(byValue(sorted))
+
+	byName := make(map[string]Value, len(values))
+	for _, v := range sorted {
+		byName[v.name] = v
+	}
+
+	var groups []valueNames
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && sorted[j].value == sorted[i].value {
+			j++
+		}
+		same := sorted[i:j]
+		i = j
+
+		n := valueNames{canonical: canonical(same), deprecated: true}
+		seen := make(map[string]bool)
+		for _, v := range same {
+			seen[v.name] = true
+			if !isDeprecated(v.annotations) {
+				n.deprecated = false
+			}
+			if v.originalName != n.canonical.originalName {
+				n.others = append(n.others, v)
+			}
+		}
+		for _, v := range same {
+			for _, alias := range explicitAliases(v) {
+				if other, ok := byName[alias]; ok && other.str != v.str {
+					log.Fatalf("alias %s of %s is the name of %s of type %s which has a different value", alias, v.originalName, other.originalName, typeName)
+				}
+				if !seen[alias] {
+					seen[alias] = true
+					n.aliases = append(n.aliases, alias)
+				}
+			}
+		}
+		groups = append(groups, n)
+	}
+
+	return groups
+}
+
+// buildNames generates the Deprecated and Aliases methods when the type has
+// deprecated constants or aliases, and the Parse function of the type when the
+// -parse flag is set.
+func (g *Generator) buildNames(typeName string, groups []valueNames) {
+	hasDeprecated, hasAliases := false, false
+	for _, n := range groups {
+		for _, v := range append([]Value{n.canonical}, n.others...) {
+			if _, ok := v.annotations[deprecatedAnnotation]; ok {
+				hasDeprecated = true
+			}
+		}
+		if len(n.names()) > 0 {
+			hasAliases = true
+		}
+	}
+
+	if hasDeprecated {
+		var set []Value
+		for _, n := range groups {
+			if n.deprecated {
+				set = append(set, n.canonical)
+			}
+		}
+		g.buildFlag(typeName, "Deprecated", "Reports whether the error code is deprecated and should no longer be used", set)
+	}
+
+	if hasAliases {
+		g.Printf("\n")
+		g.Printf("// Returns the other names of the error code, the constants sharing its value\n")
+		g.Printf("// and its former names\n")
+		g.Printf("func (i %s) Aliases() []string {\n", typeName)
+		g.Printf("\tswitch i {\n")
+		for _, n := range groups {
+			names := n.names()
+			if len(names) == 0 {
+				continue
+			}
+			quoted := make([]string, len(names))
+			for i, name := range names {
+				quoted[i] = fmt.Sprintf("%q", name)
+			}
+			g.Printf("\tcase %s:\n", n.canonical.originalName)
+			g.Printf("\t\treturn []string{%s}\n", strings.Join(quoted, ", "))
+		}
+		g.Printf("\t}\n")
+		g.Printf("\treturn nil\n")
+		g.Printf("}\n")
+	}
+
+	if !*parseFlag {
+		return
+	}
+
+	g.Printf("\n")
+	g.Printf("var _%s_byName = map[string]%s{\n", typeName, typeName)
+	for _, n := range groups {
+		g.Printf("\t%q: %s,\n", n.canonical.name, n.canonical.originalName)
+		for _, name := range n.names() {
+			g.Printf("\t%q: %s,\n", name, n.canonical.originalName)
+		}
+	}
+	g.Printf("}\n")
+	g.Printf(parseFunc, typeName)
+}
+
+// Arguments to format are:
+//
+//	[1]: type name
+const parseFunc = `
+// Returns the error code with the given name as returned by String(), or
+// one of its aliases, and whether there is one
+func Parse%[1]s(name string) (%[1]s, bool) {
+	i, ok := _%[1]s_byName[name]
+	return i, ok
+}
+`
+
+// strictWarnings returns the problems with the names of the constants which
+// make the generated methods ambiguous, reported when -strict is set.
+func strictWarnings(typeName string, groups []valueNames, links []parentLink) []string {
+	var warnings []string
+	for _, n := range groups {
+		var active []string
+		if !isDeprecated(n.canonical.annotations) {
+			active = append(active, n.canonical.originalName)
+		}
+		for _, v := range n.others {
+			if !isDeprecated(v.annotations) {
+				active = append(active, v.originalName)
+			}
+		}
+		if len(active) > 1 {
+			warnings = append(warnings, fmt.Sprintf("%s share the value %s of type %s and String() returns %s, annotate the others //ohno:deprecated or turn them into //ohno:alias", strings.Join(active, ", "), n.canonical.str, typeName, n.canonical.name))
+		}
+	}
+
+	for _, link := range links {
+		if isDeprecated(link.parent.annotations) && !isDeprecated(link.child.annotations) {
+			warnings = append(warnings, fmt.Sprintf("%s of type %s has the deprecated parent %s", link.child.originalName, typeName, link.parent.originalName))
+		}
+	}
+
+	return warnings
+}
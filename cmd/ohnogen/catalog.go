@@ -0,0 +1,276 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Catalog is the machine readable list of all the error constants of one or
+// more types in a package.
+type Catalog struct {
+	Errors []CatalogEntry `json:"errors" yaml:"errors"`
+}
+
+// CatalogEntry describes a single error constant.
+type CatalogEntry struct {
+	// Package in which the constant is declared
+	Package string `json:"package" yaml:"package"`
+	// Name of the enum type
+	Type string `json:"type" yaml:"type"`
+	// Name of the error as returned by String(), i.e. with the prefix trimmed
+	Name string `json:"name" yaml:"name"`
+	// Name of the Go constant
+	Identifier string `json:"identifier" yaml:"identifier"`
+	// Code as returned by Code(), formatted to the configured base
+	Code string `json:"code" yaml:"code"`
+	// Value of the constant in decimal
+	Value string `json:"value" yaml:"value"`
+	// Description taken from the line comment
+	Description string `json:"description" yaml:"description"`
+	// What can be done about the error
+	Hint string `json:"hint,omitempty" yaml:"hint,omitempty"`
+	// Whether the constant is annotated //ohno:deprecated
+	Deprecated bool `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	// Former names of the constant from the //ohno:alias directive
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// Annotations taken from the //ohno: directives
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+	// File (relative to the package directory) and line of the declaration
+	File string `json:"file" yaml:"file"`
+	Line int    `json:"line" yaml:"line"`
+	// Set in the lock file for constants which were deprecated and removed
+	Retired bool `json:"retired,omitempty" yaml:"retired,omitempty"`
+}
+
+// catalog builds the catalog of the named types.
+func (g *Generator) catalog(typeNames []string) *Catalog {
+	c := &Catalog{
+		Errors: []CatalogEntry{},
+	}
+
+	for _, typeName := range typeNames {
+		values := g.values(typeName)
+		sort.Stable(byValue(values))
+		for _, v := range values {
+			c.Errors = append(c.Errors, CatalogEntry{
+				Package:     g.pkg.name,
+				Type:        typeName,
+				Name:        v.name,
+				Identifier:  v.originalName,
+				Code:        g.code(v),
+				Value:       v.str,
+				Description: v.description,
+				Hint:        v.hint,
+				Deprecated:  isDeprecated(v.annotations),
+				Aliases:     explicitAliases(v),
+				Annotations: v.annotations,
+				File:        filepath.Base(v.position.Filename),
+				Line:        v.position.Line,
+			})
+		}
+	}
+
+	return c
+}
+
+// code returns the code of the value the same way the generated Code() method
+// would.
+func (g *Generator) code(v Value) string {
+	if v.signed {
+		return g.codePrefix + strconv.FormatInt(int64(v.value), g.codeBase)
+	}
+	return g.codePrefix + strconv.FormatUint(v.value, g.codeBase)
+}
+
+// formatCatalog returns the catalog in the requested format.
+func formatCatalog(c *Catalog, format string) []byte {
+	var buf bytes.Buffer
+	if err := c.encode(&buf, format); err != nil {
+		log.Fatalf("writing catalog: %s", err)
+	}
+
+	return buf.Bytes()
+}
+
+// writeOutput writes src to the named file, or to the standard output if name
+// is empty.
+func writeOutput(name string, src []byte) {
+	var err error
+	if name == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(name, src, 0644)
+	}
+	if err != nil {
+		log.Fatalf("writing output: %s", err)
+	}
+}
+
+// encode writes the catalog to w in one of the formats json, yaml, markdown
+// or html.
+func (c *Catalog) encode(w io.Writer, format string) error {
+	switch format {
+	case "json", "":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "markdown", "md":
+		return markdownCatalog.Execute(w, c.groups())
+	case "html":
+		return htmlCatalog.Execute(w, c.groups())
+	default:
+		return fmt.Errorf("unknown catalog format %q, must be one of json, yaml, markdown, html", format)
+	}
+}
+
+// readCatalog reads a catalog written in json or yaml. The format is guessed
+// from the file extension, json is assumed for anything other than .yaml or
+// .yml.
+func readCatalog(name string) (*Catalog, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := new(Catalog)
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, c)
+	default:
+		err = json.Unmarshal(data, c)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading catalog %s: %w", name, err)
+	}
+
+	return c, nil
+}
+
+// catalogGroup holds the entries of a single type for the human readable
+// formats.
+type catalogGroup struct {
+	Package string
+	Type    string
+	Errors  []CatalogEntry
+}
+
+// groups splits the entries by package and type keeping their order.
+func (c *Catalog) groups() []catalogGroup {
+	var groups []catalogGroup
+	for _, e := range c.Errors {
+		n := len(groups)
+		if n == 0 || groups[n-1].Package != e.Package || groups[n-1].Type != e.Type {
+			groups = append(groups, catalogGroup{Package: e.Package, Type: e.Type})
+			n++
+		}
+		groups[n-1].Errors = append(groups[n-1].Errors, e)
+	}
+
+	return groups
+}
+
+// canonical returns a single entry per value, the one of the constant whose
+// name String() returns as chosen by [canonical], so that every output names
+// a value the way the Go side does.
+func (g catalogGroup) canonical() []CatalogEntry {
+	var entries []CatalogEntry
+	for i := 0; i < len(g.Errors); {
+		j := i + 1
+		for j < len(g.Errors) && g.Errors[j].Value == g.Errors[i].Value {
+			j++
+		}
+		chosen := g.Errors[i]
+		for _, e := range g.Errors[i:j] {
+			if !e.Deprecated {
+				chosen = e
+				break
+			}
+		}
+		entries = append(entries, chosen)
+		i = j
+	}
+
+	return entries
+}
+
+// FormatAnnotations returns the annotations as a sorted, comma separated list
+// of key=value pairs.
+func (e CatalogEntry) FormatAnnotations() string {
+	keys := make([]string, 0, len(e.Annotations))
+	for k := range e.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		keys[i] = k + "=" + e.Annotations[k]
+	}
+
+	return strings.Join(keys, ", ")
+}
+
+// markdownCell escapes the characters which would break a markdown table.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+var markdownCatalog = template.Must(template.New("markdown").Funcs(template.FuncMap{
+	"cell": markdownCell,
+	"join": strings.Join,
+}).Parse(`# Error catalog
+{{range .}}
+## {{.Package}}.{{.Type}}
+
+| Code | Name | Description | Hint | Annotations | Source |
+| ---- | ---- | ----------- | ---- | ----------- | ------ |
+{{range .Errors}}| {{cell .Code}} | {{cell .Name}}{{if .Deprecated}} (deprecated){{end}}{{with .Aliases}} (alias {{cell (join . ", ")}}){{end}} | {{cell .Description}} | {{cell .Hint}} | {{cell .FormatAnnotations}} | {{.File}}:{{.Line}} |
+{{end}}{{end}}`))
+
+var htmlCatalog = htmltemplate.Must(htmltemplate.New("html").Funcs(htmltemplate.FuncMap{
+	"join": strings.Join,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Error catalog</title>
+</head>
+<body>
+<h1>Error catalog</h1>
+{{range .}}
+<h2>{{.Package}}.{{.Type}}</h2>
+<table>
+<thead>
+<tr><th>Code</th><th>Name</th><th>Description</th><th>Hint</th><th>Annotations</th><th>Source</th></tr>
+</thead>
+<tbody>
+{{range .Errors}}<tr><td>{{.Code}}</td><td>{{if .Deprecated}}<del>{{.Name}}</del> (deprecated){{else}}{{.Name}}{{end}}{{with .Aliases}} (alias {{join . ", "}}){{end}}</td><td>{{.Description}}</td><td>{{.Hint}}</td><td>{{.FormatAnnotations}}</td><td>{{.File}}:{{.Line}}</td></tr>
+{{end}}</tbody>
+</table>
+{{end}}</body>
+</html>
+`))
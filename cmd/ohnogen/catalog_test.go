@@ -0,0 +1,52 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalogGroupCanonical(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []CatalogEntry
+		want    []string // keys of the canonical entries in order
+	}{
+		{
+			name:    "distinct values",
+			entries: []CatalogEntry{entry("A", "X", "1"), entry("A", "Y", "2")},
+			want:    []string{"A.X", "A.Y"},
+		},
+		{
+			name:    "first of a value",
+			entries: []CatalogEntry{entry("A", "X", "1"), entry("A", "Y", "1")},
+			want:    []string{"A.X"},
+		},
+		{
+			name:    "deprecated before its replacement",
+			entries: []CatalogEntry{deprecatedEntry("A", "Old", "1"), entry("A", "New", "1"), entry("A", "Z", "2")},
+			want:    []string{"A.New", "A.Z"},
+		},
+		{
+			name:    "all deprecated",
+			entries: []CatalogEntry{deprecatedEntry("A", "X", "1"), deprecatedEntry("A", "Y", "1")},
+			want:    []string{"A.X"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range (catalogGroup{Type: "A", Errors: tt.entries}).canonical() {
+				got = append(got, e.key())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("canonical %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
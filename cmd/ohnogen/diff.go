@@ -0,0 +1,258 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// change is a single difference between two catalogs. Old is the zero value
+// for added codes and New for removed ones.
+type change struct {
+	Old, New CatalogEntry
+	Breaking bool
+}
+
+// catalogDiff holds the differences between two catalogs grouped by kind.
+type catalogDiff struct {
+	Added       []change
+	Removed     []change
+	Renamed     []change
+	Renumbered  []change
+	Redescribed []change
+	Deprecated  []change
+}
+
+// breaking reports whether any of the changes breaks clients.
+func (d *catalogDiff) breaking() bool {
+	for _, group := range d.groups() {
+		for _, c := range group.changes {
+			if c.Breaking {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// changeGroup is a kind of change with its heading.
+type changeGroup struct {
+	title   string
+	changes []change
+}
+
+// groups returns the changes along with their headings in the order they
+// are printed.
+func (d *catalogDiff) groups() []changeGroup {
+	return []changeGroup{
+		{"Added", d.Added},
+		{"Removed", d.Removed},
+		{"Renamed", d.Renamed},
+		{"Renumbered", d.Renumbered},
+		{"Re-described", d.Redescribed},
+		{"Deprecated", d.Deprecated},
+	}
+}
+
+// diffCatalogs compares the catalogs in the files oldName and newName and
+// writes the changelog in the requested format to outputName, or to the
+// standard output if outputName is empty. It returns the exit status, which
+// is 1 if there are breaking changes.
+func diffCatalogs(oldName, newName, format, outputName string) int {
+	oldCatalog, err := readCatalog(oldName)
+	if err != nil {
+		log.Fatal(err)
+	}
+	newCatalog, err := readCatalog(newName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	d := compareCatalogs(oldCatalog, newCatalog)
+
+	var buf bytes.Buffer
+	switch format {
+	case "text", "":
+		d.writeText(&buf)
+	case "markdown", "md":
+		d.writeMarkdown(&buf)
+	default:
+		log.Fatalf("unknown diff format %q, must be one of text, markdown", format)
+	}
+	writeOutput(outputName, buf.Bytes())
+
+	if d.breaking() {
+		return 1
+	}
+	return 0
+}
+
+// compareCatalogs works out what changed from the old to the new catalog.
+// Entries retired in a lock file are ignored. A removed and an added name of
+// the same type sharing a value are considered a rename, which does not break
+// clients when the old name stays around deprecated: still declared or
+// retired in the new catalog.
+func compareCatalogs(oldCatalog, newCatalog *Catalog) *catalogDiff {
+	d := new(catalogDiff)
+	oldEntries := activeEntries(oldCatalog)
+	newEntries := activeEntries(newCatalog)
+
+	oldByName := make(map[string]CatalogEntry, len(oldEntries))
+	for _, e := range oldEntries {
+		oldByName[e.key()] = e
+	}
+	newByName := make(map[string]CatalogEntry, len(newEntries))
+	for _, e := range newEntries {
+		newByName[e.key()] = e
+	}
+	retired := make(map[string]bool)
+	for _, e := range newCatalog.Errors {
+		if e.Retired {
+			retired[e.key()] = true
+		}
+	}
+
+	var added []CatalogEntry
+	for _, n := range newEntries {
+		o, ok := oldByName[n.key()]
+		if !ok {
+			added = append(added, n)
+			continue
+		}
+		if o.Value != n.Value {
+			d.Renumbered = append(d.Renumbered, change{Old: o, New: n, Breaking: true})
+		}
+		if o.Description != n.Description {
+			d.Redescribed = append(d.Redescribed, change{Old: o, New: n})
+		}
+		if !o.deprecated() && n.deprecated() {
+			d.Deprecated = append(d.Deprecated, change{Old: o, New: n})
+		}
+	}
+
+	renamedTo := make(map[string]bool)
+	for _, o := range oldEntries {
+		n, kept := newByName[o.key()]
+		if kept && (!n.deprecated() || n.Value != o.Value) {
+			continue
+		}
+		// The old name is still around if it is deprecated or retired.
+		deprecated := o.deprecated() || kept || retired[o.key()]
+
+		renamed := false
+		for _, n := range added {
+			if renamedTo[n.key()] || n.Type != o.Type || n.Value != o.Value {
+				continue
+			}
+			renamedTo[n.key()] = true
+			d.Renamed = append(d.Renamed, change{Old: o, New: n, Breaking: !deprecated})
+			renamed = true
+			break
+		}
+		if !renamed && !kept {
+			d.Removed = append(d.Removed, change{Old: o, Breaking: !deprecated})
+		}
+	}
+
+	for _, n := range added {
+		if !renamedTo[n.key()] {
+			d.Added = append(d.Added, change{New: n})
+		}
+	}
+
+	return d
+}
+
+// activeEntries returns the entries of the catalog which have not been
+// retired.
+func activeEntries(c *Catalog) []CatalogEntry {
+	entries := make([]CatalogEntry, 0, len(c.Errors))
+	for _, e := range c.Errors {
+		if !e.Retired {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// describe returns a single line summary of the change for the given group.
+func (c change) describe(title string) string {
+	var s string
+	switch title {
+	case "Added":
+		s = fmt.Sprintf("%s [%s]: %s", c.New.key(), c.New.Code, c.New.Description)
+	case "Removed":
+		s = fmt.Sprintf("%s [%s]: %s", c.Old.key(), c.Old.Code, c.Old.Description)
+		if !c.Breaking {
+			s += " (was deprecated)"
+		}
+	case "Renamed":
+		s = fmt.Sprintf("%s -> %s [%s]", c.Old.key(), c.New.key(), c.New.Code)
+		if !c.Breaking {
+			s += " (old name deprecated)"
+		}
+	case "Renumbered":
+		s = fmt.Sprintf("%s [%s -> %s]", c.New.key(), c.Old.Code, c.New.Code)
+	case "Re-described":
+		s = fmt.Sprintf("%s [%s]: %q -> %q", c.New.key(), c.New.Code, c.Old.Description, c.New.Description)
+	case "Deprecated":
+		s = fmt.Sprintf("%s [%s]: %s", c.New.key(), c.New.Code, c.New.Description)
+	}
+
+	if c.Breaking {
+		s += " (breaking)"
+	}
+	return s
+}
+
+// writeText writes the changelog as plain text.
+func (d *catalogDiff) writeText(buf *bytes.Buffer) {
+	empty := true
+	for _, group := range d.groups() {
+		if len(group.changes) == 0 {
+			continue
+		}
+		if !empty {
+			buf.WriteString("\n")
+		}
+		empty = false
+		fmt.Fprintf(buf, "%s:\n", group.title)
+		for _, c := range group.changes {
+			fmt.Fprintf(buf, "\t%s\n", c.describe(group.title))
+		}
+	}
+
+	if empty {
+		buf.WriteString("No changes\n")
+	}
+}
+
+// writeMarkdown writes the changelog as markdown sections.
+func (d *catalogDiff) writeMarkdown(buf *bytes.Buffer) {
+	buf.WriteString("# Error code changes\n")
+	if d.breaking() {
+		buf.WriteString("\n**This release contains breaking changes to the error codes.**\n")
+	}
+
+	empty := true
+	for _, group := range d.groups() {
+		if len(group.changes) == 0 {
+			continue
+		}
+		empty = false
+		fmt.Fprintf(buf, "\n## %s\n\n", group.title)
+		for _, c := range group.changes {
+			fmt.Fprintf(buf, "- %s\n", strings.ReplaceAll(c.describe(group.title), "\n", " "))
+		}
+	}
+
+	if empty {
+		buf.WriteString("\nNo changes\n")
+	}
+}
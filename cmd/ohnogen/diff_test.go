@@ -0,0 +1,107 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func retiredEntry(typ, name, value string) CatalogEntry {
+	e := deprecatedEntry(typ, name, value)
+	e.Retired = true
+	return e
+}
+
+func TestCompareCatalogs(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      []CatalogEntry
+		new      []CatalogEntry
+		want     map[string][]string // group title to the changes described
+		breaking bool
+	}{
+		{
+			name: "unchanged",
+			old:  []CatalogEntry{entry("A", "X", "1")},
+			new:  []CatalogEntry{entry("A", "X", "1")},
+			want: map[string][]string{},
+		},
+		{
+			name: "added",
+			old:  []CatalogEntry{entry("A", "X", "1")},
+			new:  []CatalogEntry{entry("A", "X", "1"), entry("A", "Y", "2")},
+			want: map[string][]string{"Added": {"A.Y []: "}},
+		},
+		{
+			name:     "removed",
+			old:      []CatalogEntry{entry("A", "X", "1"), entry("A", "Y", "2")},
+			new:      []CatalogEntry{entry("A", "X", "1")},
+			want:     map[string][]string{"Removed": {"A.Y []:  (breaking)"}},
+			breaking: true,
+		},
+		{
+			name: "removed after deprecation",
+			old:  []CatalogEntry{entry("A", "X", "1"), deprecatedEntry("A", "Y", "2")},
+			new:  []CatalogEntry{entry("A", "X", "1"), retiredEntry("A", "Y", "2")},
+			want: map[string][]string{"Removed": {"A.Y []:  (was deprecated)"}},
+		},
+		{
+			name:     "renumbered",
+			old:      []CatalogEntry{entry("A", "X", "1")},
+			new:      []CatalogEntry{entry("A", "X", "2")},
+			want:     map[string][]string{"Renumbered": {"A.X [ -> ] (breaking)"}},
+			breaking: true,
+		},
+		{
+			name:     "renamed",
+			old:      []CatalogEntry{entry("A", "X", "1")},
+			new:      []CatalogEntry{entry("A", "Y", "1")},
+			want:     map[string][]string{"Renamed": {"A.X -> A.Y [] (breaking)"}},
+			breaking: true,
+		},
+		{
+			name: "renamed keeping the old name deprecated",
+			old:  []CatalogEntry{entry("A", "X", "1")},
+			new:  []CatalogEntry{deprecatedEntry("A", "X", "1"), entry("A", "Y", "1")},
+			want: map[string][]string{
+				"Renamed":    {"A.X -> A.Y [] (old name deprecated)"},
+				"Deprecated": {"A.X []: "},
+			},
+		},
+		{
+			name: "renamed after the old name was retired",
+			old:  []CatalogEntry{deprecatedEntry("A", "X", "1"), entry("A", "Y", "1")},
+			new:  []CatalogEntry{retiredEntry("A", "X", "1"), entry("A", "Y", "1")},
+			want: map[string][]string{"Removed": {"A.X []:  (was deprecated)"}},
+		},
+		{
+			name: "deprecated",
+			old:  []CatalogEntry{entry("A", "X", "1")},
+			new:  []CatalogEntry{deprecatedEntry("A", "X", "1")},
+			want: map[string][]string{"Deprecated": {"A.X []: "}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := compareCatalogs(&Catalog{Errors: tt.old}, &Catalog{Errors: tt.new})
+
+			got := make(map[string][]string)
+			for _, group := range d.groups() {
+				for _, c := range group.changes {
+					got[group.title] = append(got[group.title], c.describe(group.title))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if d.breaking() != tt.breaking {
+				t.Errorf("breaking %v, want %v", d.breaking(), tt.breaking)
+			}
+		})
+	}
+}
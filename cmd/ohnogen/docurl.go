@@ -0,0 +1,72 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"strings"
+	"text/template"
+)
+
+// docURLAnnotation overrides the documentation URL template for a single
+// constant.
+const docURLAnnotation = "docurl"
+
+// docURLData is the data the documentation URL templates are executed with.
+type docURLData struct {
+	Package    string // Package in which the constant is declared
+	Type       string // Name of the enum type
+	Name       string // Name as returned by String()
+	Identifier string // Name of the Go constant
+	Code       string // Code as returned by Code()
+	Value      string // Value in decimal
+}
+
+// parseDocURL parses a documentation URL template, exiting if it is invalid.
+func parseDocURL(name, text string) *template.Template {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		log.Fatalf("invalid documentation URL template %q: %s", text, err)
+	}
+	return t
+}
+
+// docURL returns the documentation URL of v, from its docurl annotation if
+// it has one or else from the -docurl template, or an empty string if there
+// is neither.
+func (g *Generator) docURL(typeName string, v Value) string {
+	t := g.docURLTemplate
+	if text, ok := v.annotations[docURLAnnotation]; ok {
+		t = parseDocURL(v.originalName, text)
+	}
+	if t == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	err := t.Execute(&b, docURLData{
+		Package:    g.pkg.name,
+		Type:       typeName,
+		Name:       v.name,
+		Identifier: v.originalName,
+		Code:       g.code(v),
+		Value:      v.str,
+	})
+	if err != nil {
+		log.Fatalf("documentation URL of %s: %s", v.originalName, err)
+	}
+	return b.String()
+}
+
+// buildDocURL generates the DocURL method when -docurl is set or a constant
+// has a docurl annotation.
+func (g *Generator) buildDocURL(typeName string, groups []valueNames) {
+	urls := make([]string, len(groups))
+	for i, n := range groups {
+		urls[i] = g.docURL(typeName, n.canonical)
+	}
+	g.buildText(typeName, "DocURL", "Returns the URL of the documentation of the error code", groups, urls)
+}
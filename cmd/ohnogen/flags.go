@@ -0,0 +1,100 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"strconv"
+	"strings"
+)
+
+// retryableAnnotation marks the constants of errors which may go away when
+// the failed operation is tried again.
+const retryableAnnotation = "retryable"
+
+// flagged returns the constants for which the boolean annotation key is true,
+// with constants sharing a value appearing only once, and whether any
+// constant has the annotation at all. It exits if a value is not a boolean or
+// if constants sharing a value disagree.
+func flagged(values []Value, key string) (set []Value, used bool) {
+	byValue := make(map[string]bool)
+	for _, v := range values {
+		raw, ok := v.annotations[key]
+		if !ok {
+			continue
+		}
+		used = true
+		on, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			log.Fatalf("annotation %s of %s must be true or false, found %q", key, v.originalName, raw)
+		}
+		if prev, ok := byValue[v.str]; ok {
+			if prev != on {
+				log.Fatalf("%s is annotated %s=%t but another constant with the value %s is not", v.originalName, key, on, v.str)
+			}
+			continue
+		}
+		byValue[v.str] = on
+		if on {
+			set = append(set, v)
+		}
+	}
+
+	return set, used
+}
+
+// buildText generates a method of the type named method returning the text
+// of each value, texts[i] being the one of groups[i]. Nothing is generated if
+// all the texts are empty.
+func (g *Generator) buildText(typeName, method, doc string, groups []valueNames, texts []string) {
+	found := false
+	for _, text := range texts {
+		if text != "" {
+			found = true
+		}
+	}
+	if !found {
+		return
+	}
+
+	g.Printf("\n")
+	g.Printf("// %s\n", doc)
+	g.Printf("func (i %s) %s() string {\n", typeName, method)
+	g.Printf("\tswitch i {\n")
+	for i, n := range groups {
+		if texts[i] == "" {
+			continue
+		}
+		g.Printf("\tcase %s:\n", n.canonical.originalName)
+		g.Printf("\t\treturn %q\n", texts[i])
+	}
+	g.Printf("\t}\n")
+	g.Printf("\treturn \"\"\n")
+	g.Printf("}\n")
+}
+
+// buildFlag generates a method of the type named method returning whether
+// the receiver is one of the given constants.
+func (g *Generator) buildFlag(typeName, method, doc string, set []Value) {
+	g.Printf("\n")
+	g.Printf("// %s\n", doc)
+	g.Printf("func (i %s) %s() bool {\n", typeName, method)
+	if len(set) == 0 {
+		g.Printf("\treturn false\n")
+		g.Printf("}\n")
+		return
+	}
+	names := make([]string, len(set))
+	for i, v := range set {
+		names[i] = v.originalName
+	}
+	g.Printf("\tswitch i {\n")
+	g.Printf("\tcase %s:\n", strings.Join(names, ", "))
+	g.Printf("\t\treturn true\n")
+	g.Printf("\t}\n")
+	g.Printf("\treturn false\n")
+	g.Printf("}\n")
+}
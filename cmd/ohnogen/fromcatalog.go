@@ -0,0 +1,171 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generateFromCatalog generates the type declarations, the constants and
+// their methods from the entries of the catalog in the named file. If
+// typeNames is empty every type in the catalog is generated. It returns the
+// names of the generated types.
+func (g *Generator) generateFromCatalog(catalogName string, typeNames []string) []string {
+	c, err := readCatalog(catalogName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	wanted := make(map[string]bool, len(typeNames))
+	for _, typeName := range typeNames {
+		wanted[typeName] = true
+	}
+
+	pkgName := ""
+	var order []string
+	valuesByType := make(map[string][]Value)
+	for _, e := range activeEntries(c) {
+		if len(wanted) > 0 && !wanted[e.Type] {
+			continue
+		}
+		if pkgName == "" {
+			pkgName = e.Package
+		} else if e.Package != pkgName {
+			log.Fatalf("catalog %s holds entries of packages %s and %s, use -type to select types of a single package", catalogName, pkgName, e.Package)
+		}
+		if _, ok := valuesByType[e.Type]; !ok {
+			order = append(order, e.Type)
+		}
+		valuesByType[e.Type] = append(valuesByType[e.Type], g.catalogValue(e))
+	}
+
+	if len(typeNames) == 0 {
+		typeNames = order
+	}
+	if len(typeNames) == 0 {
+		log.Fatalf("no entries found in catalog %s", catalogName)
+	}
+	if pkgName == "" {
+		log.Fatalf("no package name found in catalog %s", catalogName)
+	}
+
+	g.pkg = &Package{name: pkgName}
+	for _, typeName := range typeNames {
+		values := valuesByType[typeName]
+		if len(values) == 0 {
+			log.Fatalf("no values defined for type %s in catalog %s", typeName, catalogName)
+		}
+		fitSignedness(typeName, values)
+		g.declareType(typeName, values)
+		g.generate(typeName, values)
+	}
+
+	return typeNames
+}
+
+// catalogValue converts a catalog entry to the value it describes.
+func (g *Generator) catalogValue(e CatalogEntry) Value {
+	if e.Identifier == "" {
+		log.Fatalf("entry %s of the catalog has no identifier", e.key())
+	}
+
+	v := Value{
+		originalName: e.Identifier,
+		name:         e.Name,
+		str:          e.Value,
+		description:  e.Description,
+		hint:         e.Hint,
+		annotations:  e.Annotations,
+	}
+	// Values without a sign may be above math.MaxInt64, they are only
+	// unsigned if they do not fit in an int64.
+	if strings.HasPrefix(e.Value, "-") || strings.HasPrefix(e.Value, "+") {
+		i64, err := strconv.ParseInt(e.Value, 10, 64)
+		if err != nil {
+			log.Fatalf("value %q of %s is not an integer: %s", e.Value, e.key(), err)
+		}
+		v.value = uint64(i64)
+		v.signed = true
+	} else {
+		u64, err := strconv.ParseUint(e.Value, 10, 64)
+		if err != nil {
+			log.Fatalf("value %q of %s is not an integer: %s", e.Value, e.key(), err)
+		}
+		v.value = u64
+		v.signed = u64 <= math.MaxInt64
+	}
+	if v.name == "" {
+		v.name = strings.TrimPrefix(v.originalName, g.trimPrefix)
+	}
+
+	return v
+}
+
+// fitSignedness makes every value of the type unsigned if one of them does
+// not fit in an int64, so that the type is declared as uint64. A type holding
+// negative values as well cannot be declared and is fatal.
+func fitSignedness(typeName string, values []Value) {
+	unsigned := false
+	for _, v := range values {
+		if !v.signed {
+			unsigned = true
+			break
+		}
+	}
+	if !unsigned {
+		return
+	}
+
+	for i := range values {
+		if strings.HasPrefix(values[i].str, "-") {
+			log.Fatalf("type %s holds the negative value %s and values above %d", typeName, values[i].str, uint64(math.MaxInt64))
+		}
+		values[i].signed = false
+	}
+}
+
+// declareType prints the declaration of the type and its constants, with the
+// descriptions as line comments and the annotations as directives so that the
+// generated source describes the same catalog again.
+func (g *Generator) declareType(typeName string, values []Value) {
+	kind := "int"
+	if !values[0].signed {
+		kind = "uint64"
+	}
+	g.Printf("\n")
+	g.Printf("type %s %s\n", typeName, kind)
+	g.Printf("\n")
+	g.Printf("const (\n")
+	for _, v := range values {
+		keys := make([]string, 0, len(v.annotations))
+		for k := range v.annotations {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if v.annotations[k] == "true" {
+				g.Printf("\t%s%s\n", annotationPrefix, k)
+			} else {
+				g.Printf("\t%s%s=%s\n", annotationPrefix, k, v.annotations[k])
+			}
+		}
+
+		g.Printf("\t%s %s = %s", v.originalName, typeName, v.str)
+		comment := strings.Join(strings.Fields(v.description), " ")
+		if _, ok := v.annotations[hintAnnotation]; !ok && v.hint != "" {
+			comment += " | hint: " + strings.Join(strings.Fields(v.hint), " ")
+		}
+		if comment != "" {
+			g.Printf(" // %s", comment)
+		}
+		g.Printf("\n")
+	}
+	g.Printf(")\n\n")
+}
@@ -0,0 +1,98 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// roundTripCatalog covers negative, shared, deprecated, aliased and unsigned
+// values. Its entries are sorted by value the way catalog returns them.
+var roundTripCatalog = &Catalog{Errors: []CatalogEntry{
+	{Package: "roundtrip", Type: "Status", Name: "Broken", Identifier: "Broken", Code: "-1", Value: "-1", Description: "The service is broken"},
+	{Package: "roundtrip", Type: "Status", Name: "NotFound", Identifier: "NotFound", Code: "1", Value: "1", Description: "The resource was not found", Hint: "Check the identifier"},
+	{Package: "roundtrip", Type: "Status", Name: "Missing", Identifier: "Missing", Code: "1", Value: "1", Description: "The resource is missing", Deprecated: true, Annotations: map[string]string{"deprecated": "true"}},
+	{Package: "roundtrip", Type: "Status", Name: "Busy", Identifier: "Busy", Code: "2", Value: "2", Description: "The service is busy", Aliases: []string{"Occupied"}, Annotations: map[string]string{"alias": "Occupied", "retryable": "true"}},
+	{Package: "roundtrip", Type: "Flags", Name: "Small", Identifier: "Small", Code: "1", Value: "1", Description: "A small flag"},
+	{Package: "roundtrip", Type: "Flags", Name: "Huge", Identifier: "Huge", Code: "18446744073709551615", Value: "18446744073709551615", Description: "A flag above the int64 range"},
+}}
+
+// generateRoundTrip writes roundTripCatalog to a file and generates the Go
+// code from it.
+func generateRoundTrip(t *testing.T) []byte {
+	data, err := json.Marshal(roundTripCatalog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "catalog.json")
+	if err := os.WriteFile(name, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	withArgs(t, "fromcatalog", "catalog.json")
+	g := Generator{lineComment: true, codeBase: 10}
+	g.generateFromCatalog(name, nil)
+	return g.format()
+}
+
+func TestFromCatalogGolden(t *testing.T) {
+	checkGolden(t, "fromcatalog.golden", generateRoundTrip(t))
+}
+
+func TestFromCatalogRoundTrip(t *testing.T) {
+	src := generateRoundTrip(t)
+	name := filepath.Join(t.TempDir(), "roundtrip.go")
+	if err := os.WriteFile(name, src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	types := []string{"Status", "Flags"}
+	g := Generator{lineComment: true, codeBase: 10}
+	g.parsePackage([]string{name}, nil, types[0])
+	got := g.catalog(types)
+	for i := range got.Errors {
+		if got.Errors[i].File != "roundtrip.go" || got.Errors[i].Line == 0 {
+			t.Errorf("%s declared at %s:%d", got.Errors[i].key(), got.Errors[i].File, got.Errors[i].Line)
+		}
+		got.Errors[i].File = ""
+		got.Errors[i].Line = 0
+	}
+	if !reflect.DeepEqual(got, roundTripCatalog) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		wantJSON, _ := json.MarshalIndent(roundTripCatalog, "", "  ")
+		t.Errorf("catalog of the generated code\n%s\nwant\n%s", gotJSON, wantJSON)
+	}
+}
+
+func TestCatalogValue(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   uint64
+		signed bool
+	}{
+		{value: "0", want: 0, signed: true},
+		{value: "42", want: 42, signed: true},
+		{value: "+42", want: 42, signed: true},
+		{value: "-1", want: math.MaxUint64, signed: true},
+		{value: strconv.FormatInt(math.MaxInt64, 10), want: math.MaxInt64, signed: true},
+		{value: strconv.FormatUint(math.MaxInt64+1, 10), want: math.MaxInt64 + 1, signed: false},
+		{value: strconv.FormatUint(math.MaxUint64, 10), want: math.MaxUint64, signed: false},
+	}
+
+	var g Generator
+	for _, tt := range tests {
+		v := g.catalogValue(CatalogEntry{Type: "T", Name: "X", Identifier: "X", Value: tt.value})
+		if v.value != tt.want || v.signed != tt.signed {
+			t.Errorf("catalogValue(%s) = %d signed %t, want %d signed %t", tt.value, v.value, v.signed, tt.want, tt.signed)
+		}
+	}
+}
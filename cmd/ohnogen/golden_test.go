@@ -0,0 +1,87 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// checkGolden compares got with the named file in testdata/golden, or
+// rewrites the file when the tests run with -update.
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s differs from the golden file, run the tests with -update if intended:\n%s", name, got)
+	}
+}
+
+// withArgs sets the command line shown in the headers of the generated files
+// for the duration of the test.
+func withArgs(t *testing.T, args ...string) {
+	saved := os.Args
+	os.Args = append([]string{"ohnogen"}, args...)
+	t.Cleanup(func() { os.Args = saved })
+}
+
+// fatalEnv names the test the child process started by expectFatal runs.
+const fatalEnv = "OHNOGEN_FATAL_TEST"
+
+// expectFatal runs fn in a child process of the test binary and checks that
+// it exits through log.Fatal with a message containing want.
+func expectFatal(t *testing.T, want string, fn func()) {
+	t.Helper()
+	if os.Getenv(fatalEnv) == t.Name() {
+		fn()
+		os.Exit(0)
+	}
+
+	parts := strings.Split(t.Name(), "/")
+	for i, p := range parts {
+		parts[i] = "^" + regexp.QuoteMeta(p) + "$"
+	}
+	cmd := exec.Command(os.Args[0], "-test.run="+strings.Join(parts, "/"))
+	cmd.Env = append(os.Environ(), fatalEnv+"="+t.Name())
+	out, err := cmd.CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("did not exit through log.Fatal (%v):\n%s", err, out)
+	}
+	if !strings.Contains(string(out), want) {
+		t.Errorf("fatal message does not contain %q:\n%s", want, out)
+	}
+}
+
+// emitterCatalog is the catalog rendered by the golden tests of the other
+// languages. UserError shares the value 1 between a deprecated constant and
+// its replacement.
+var emitterCatalog = &Catalog{Errors: []CatalogEntry{
+	{Package: "users", Type: "UserError", Name: "Missing", Identifier: "ErrMissing", Code: "0x1", Value: "1", Description: "The user is missing", Deprecated: true},
+	{Package: "users", Type: "UserError", Name: "NotFound", Identifier: "ErrNotFound", Code: "0x1", Value: "1", Description: "The user was not found", Hint: "Check the user id"},
+	{Package: "users", Type: "UserError", Name: "HTTPTimeout", Identifier: "ErrHTTPTimeout", Code: "0x2", Value: "2", Description: "The \"upstream\" timed out */"},
+	{Package: "users", Type: "UserError", Name: "Internal", Identifier: "ErrInternal", Code: "0x3", Value: "3", Description: "An internal error occurred"},
+	{Package: "users", Type: "DBError", Name: "ConnectionLost", Identifier: "ConnectionLost", Code: "0xa", Value: "10", Description: "The connection was lost"},
+}}
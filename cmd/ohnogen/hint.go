@@ -0,0 +1,39 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import "strings"
+
+// hintAnnotation tells what can be done about an error. It can also be given
+// as a "| hint: text" segment of the line comment.
+const hintAnnotation = "hint"
+
+// splitHint splits a line comment like "Token expired | hint: log in again"
+// into the description and the hint. Segments other than the hint are kept in
+// the description.
+func splitHint(comment string) (description, hint string) {
+	parts := strings.Split(comment, "|")
+	kept := parts[:1]
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(part, ":")
+		if found && strings.TrimSpace(key) == hintAnnotation {
+			hint = strings.TrimSpace(value)
+			continue
+		}
+		kept = append(kept, part)
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "|")), hint
+}
+
+// buildHint generates the Hint method when a constant has a hint.
+func (g *Generator) buildHint(typeName string, groups []valueNames) {
+	hints := make([]string, len(groups))
+	for i, n := range groups {
+		hints[i] = n.canonical.hint
+	}
+	g.buildText(typeName, "Hint", "Returns what can be done about the error", groups, hints)
+}
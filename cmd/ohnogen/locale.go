@@ -0,0 +1,159 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// localizedAnnotationPrefix starts the annotations giving the description in
+// another language, like desc.de=Nicht gefunden.
+const localizedAnnotationPrefix = "desc."
+
+// locales holds the descriptions read from the -locales directory by
+// language, type and name.
+type locales map[string]map[string]map[string]string
+
+// normalizeLang turns a BCP 47 language tag like de_CH or de-CH into the
+// form used as key of the generated lookup, de-ch.
+func normalizeLang(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
+
+// readLocales reads the <lang>.yaml files of dir. Each file maps the names
+// of the types to a map of the names of the constants, as returned by
+// String(), to their description in the language of the file.
+func readLocales(dir string) locales {
+	files, err := filepath.Glob(filepath.Join(dir, "*.y*ml"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	l := make(locales)
+	for _, name := range files {
+		ext := filepath.Ext(name)
+		if ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var types map[string]map[string]string
+		if err := yaml.Unmarshal(data, &types); err != nil {
+			log.Fatalf("reading locale %s: %s", name, err)
+		}
+		l[normalizeLang(strings.TrimSuffix(filepath.Base(name), ext))] = types
+	}
+	if len(l) == 0 {
+		log.Fatalf("no locale files found in %s", dir)
+	}
+
+	return l
+}
+
+// localizedDescriptions returns the description of each value of the type
+// in every language it has been translated to, from the locale files and the
+// desc.<lang> annotations, which take precedence. It exits if a locale file
+// names a constant the type does not have.
+func (g *Generator) localizedDescriptions(typeName string, groups []valueNames) map[string][]string {
+	byLang := make(map[string][]string)
+	set := func(lang string, i int, description string) {
+		if byLang[lang] == nil {
+			byLang[lang] = make([]string, len(groups))
+		}
+		byLang[lang][i] = description
+	}
+
+	index := make(map[string]int)
+	for i, n := range groups {
+		index[n.canonical.name] = i
+		for _, v := range n.others {
+			index[v.name] = i
+		}
+	}
+	for lang, types := range g.locales {
+		for name, description := range types[typeName] {
+			i, ok := index[name]
+			if !ok {
+				log.Fatalf("locale %s has a description for %s which is not a constant of type %s", lang, name, typeName)
+			}
+			set(lang, i, description)
+		}
+	}
+
+	for i, n := range groups {
+		// The annotations of the canonical constant win.
+		constants := append(append([]Value(nil), n.others...), n.canonical)
+		for _, v := range constants {
+			for key, description := range v.annotations {
+				if lang := strings.TrimPrefix(key, localizedAnnotationPrefix); lang != key && lang != "" {
+					set(normalizeLang(lang), i, description)
+				}
+			}
+		}
+	}
+
+	return byLang
+}
+
+// buildLocalized generates the LocalizedDescription method when descriptions
+// in other languages are known for the type.
+func (g *Generator) buildLocalized(typeName string, groups []valueNames) {
+	byLang := g.localizedDescriptions(typeName, groups)
+	if len(byLang) == 0 {
+		return
+	}
+
+	langs := make([]string, 0, len(byLang))
+	for lang := range byLang {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	g.addImport("strings")
+	g.Printf("\n")
+	g.Printf("var _%s_localized = map[string]map[%s]string{\n", typeName, typeName)
+	for _, lang := range langs {
+		g.Printf("\t%q: {\n", lang)
+		for i, description := range byLang[lang] {
+			if description != "" {
+				g.Printf("\t\t%s: %q,\n", groups[i].canonical.originalName, description)
+			}
+		}
+		g.Printf("\t},\n")
+	}
+	g.Printf("}\n")
+	g.Printf(localizedFunc, typeName)
+}
+
+// Arguments to format are:
+//
+//	[1]: type name
+const localizedFunc = `
+// Returns the description in the language given by the BCP 47 tag lang like
+// de-CH. If there is none in that language the description in its base
+// language (de) is returned, and if there is none either Description()
+func (i %[1]s) LocalizedDescription(lang string) string {
+	lang = strings.ReplaceAll(strings.ToLower(lang), "_", "-")
+	for {
+		if description, ok := _%[1]s_localized[lang][i]; ok {
+			return description
+		}
+		n := strings.LastIndex(lang, "-")
+		if n < 0 {
+			return i.Description()
+		}
+		lang = lang[:n]
+	}
+}
+`
@@ -0,0 +1,146 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+)
+
+// defaultLockFile is the name of the lock file in the package directory.
+const defaultLockFile = "errors.lock"
+
+// checkLock compares the constants of the named types with the catalog stored
+// in the lock file. If there are incompatible changes they are reported and
+// the program exits, otherwise the updated lock file is kept to be written by
+// writeLock once nothing else can fail.
+func (g *Generator) checkLock(typeNames []string, lockName string) {
+	lock, err := readCatalog(lockName)
+	if errors.Is(err, fs.ErrNotExist) {
+		lock = &Catalog{}
+	} else if err != nil {
+		log.Fatal(err)
+	}
+
+	updated, violations := compareLock(lock, g.catalog(typeNames), typeNames)
+	if len(violations) > 0 {
+		fmt.Fprintf(os.Stderr, "ohnogen: %s: %d incompatible change(s) to the error codes:\n", lockName, len(violations))
+		for _, v := range violations {
+			fmt.Fprintf(os.Stderr, "\t%s\n", v)
+		}
+		fmt.Fprintf(os.Stderr, "ohnogen: restore the constants, or annotate removed ones with //ohno:deprecated first\n")
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err := updated.encode(&buf, "json"); err != nil {
+		log.Fatalf("writing lock file: %s", err)
+	}
+	g.lockName = lockName
+	g.lockData = buf.Bytes()
+}
+
+// writeLock writes the lock file updated by checkLock, if any.
+func (g *Generator) writeLock() {
+	if g.lockName != "" {
+		writeOutput(g.lockName, g.lockData)
+	}
+}
+
+// compareLock checks the current catalog of the named types against the
+// locked one. It returns the catalog to be locked from now on along with a
+// description of every incompatible change. The locked entries of other types
+// are kept as they are.
+func compareLock(lock, current *Catalog, typeNames []string) (*Catalog, []string) {
+	var violations []string
+
+	checked := make(map[string]bool, len(typeNames))
+	for _, t := range typeNames {
+		checked[t] = true
+	}
+
+	currentByName := make(map[string]CatalogEntry, len(current.Errors))
+	for _, e := range current.Errors {
+		currentByName[e.key()] = e
+	}
+
+	lockedByName := make(map[string]CatalogEntry, len(lock.Errors))
+	updated := &Catalog{
+		Errors: append([]CatalogEntry{}, current.Errors...),
+	}
+	for _, l := range lock.Errors {
+		if !checked[l.Type] {
+			updated.Errors = append(updated.Errors, l)
+			continue
+		}
+		lockedByName[l.key()] = l
+		c, ok := currentByName[l.key()]
+		switch {
+		case ok && c.Value != l.Value:
+			violations = append(violations, fmt.Sprintf("%s changed value from %s to %s (%s:%d)", l.key(), l.Value, c.Value, c.File, c.Line))
+		case ok:
+		case l.Retired:
+			updated.Errors = append(updated.Errors, l)
+		case l.deprecated():
+			l.Retired = true
+			updated.Errors = append(updated.Errors, l)
+		default:
+			violations = append(violations, fmt.Sprintf("%s (value %s) was removed without being marked deprecated (was %s:%d)", l.key(), l.Value, l.File, l.Line))
+		}
+	}
+
+	for _, c := range current.Errors {
+		if _, ok := lockedByName[c.key()]; ok {
+			continue
+		}
+		for _, l := range lock.Errors {
+			if l.Type != c.Type || l.Value != c.Value {
+				continue
+			}
+			if _, ok := currentByName[l.key()]; ok {
+				// The old name is still there, so this is just an alias.
+				continue
+			}
+			violations = append(violations, fmt.Sprintf("%s (%s:%d) reuses value %s of the removed %s", c.key(), c.File, c.Line, c.Value, l.key()))
+		}
+	}
+
+	sort.SliceStable(updated.Errors, func(i, j int) bool {
+		a, b := updated.Errors[i], updated.Errors[j]
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return valueLess(a.Value, b.Value)
+	})
+
+	return updated, violations
+}
+
+// key identifies an entry within a package.
+func (e CatalogEntry) key() string {
+	return e.Type + "." + e.Name
+}
+
+// deprecated reports whether the entry has been annotated as deprecated.
+func (e CatalogEntry) deprecated() bool {
+	return e.Deprecated || isDeprecated(e.Annotations)
+}
+
+// valueLess compares two decimal values of the catalog numerically.
+func valueLess(a, b string) bool {
+	x, okX := new(big.Int).SetString(a, 10)
+	y, okY := new(big.Int).SetString(b, 10)
+	if !okX || !okY {
+		return a < b
+	}
+	return x.Cmp(y) < 0
+}
@@ -0,0 +1,124 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func entry(typ, name, value string) CatalogEntry {
+	return CatalogEntry{Type: typ, Name: name, Value: value}
+}
+
+func deprecatedEntry(typ, name, value string) CatalogEntry {
+	e := entry(typ, name, value)
+	e.Deprecated = true
+	return e
+}
+
+func TestCompareLock(t *testing.T) {
+	tests := []struct {
+		name       string
+		lock       []CatalogEntry
+		current    []CatalogEntry
+		types      []string
+		locked     []string // keys of the updated lock in order
+		violations []string // substrings of the violations in order
+	}{
+		{
+			name:    "unchanged",
+			lock:    []CatalogEntry{entry("A", "X", "1")},
+			current: []CatalogEntry{entry("A", "X", "1")},
+			types:   []string{"A"},
+			locked:  []string{"A.X"},
+		},
+		{
+			name:    "added",
+			lock:    []CatalogEntry{entry("A", "X", "1")},
+			current: []CatalogEntry{entry("A", "X", "1"), entry("A", "Y", "2")},
+			types:   []string{"A"},
+			locked:  []string{"A.X", "A.Y"},
+		},
+		{
+			name:    "deprecated",
+			lock:    []CatalogEntry{entry("A", "X", "1")},
+			current: []CatalogEntry{deprecatedEntry("A", "X", "1")},
+			types:   []string{"A"},
+			locked:  []string{"A.X"},
+		},
+		{
+			name:    "removed after deprecation",
+			lock:    []CatalogEntry{entry("A", "X", "1"), deprecatedEntry("A", "Y", "2")},
+			current: []CatalogEntry{entry("A", "X", "1")},
+			types:   []string{"A"},
+			locked:  []string{"A.X", "A.Y"},
+		},
+		{
+			name:       "removed without deprecation",
+			lock:       []CatalogEntry{entry("A", "X", "1"), entry("A", "Y", "2")},
+			current:    []CatalogEntry{entry("A", "X", "1")},
+			types:      []string{"A"},
+			locked:     []string{"A.X"},
+			violations: []string{"A.Y (value 2) was removed without being marked deprecated"},
+		},
+		{
+			name:       "renumbered",
+			lock:       []CatalogEntry{entry("A", "X", "1")},
+			current:    []CatalogEntry{entry("A", "X", "3")},
+			types:      []string{"A"},
+			locked:     []string{"A.X"},
+			violations: []string{"A.X changed value from 1 to 3"},
+		},
+		{
+			name:       "value of a removed constant reused",
+			lock:       []CatalogEntry{deprecatedEntry("A", "X", "1")},
+			current:    []CatalogEntry{entry("A", "Y", "1")},
+			types:      []string{"A"},
+			locked:     []string{"A.Y", "A.X"},
+			violations: []string{"A.Y (:0) reuses value 1 of the removed A.X"},
+		},
+		{
+			name:    "other types are kept",
+			lock:    []CatalogEntry{entry("A", "X", "1"), entry("B", "Z", "1")},
+			current: []CatalogEntry{entry("B", "Z", "1"), entry("B", "W", "2")},
+			types:   []string{"B"},
+			locked:  []string{"A.X", "B.Z", "B.W"},
+		},
+		{
+			name:       "several types",
+			lock:       []CatalogEntry{entry("A", "X", "1"), entry("B", "Z", "1")},
+			current:    []CatalogEntry{entry("A", "X", "2"), entry("B", "Z", "1")},
+			types:      []string{"A", "B"},
+			locked:     []string{"A.X", "B.Z"},
+			violations: []string{"A.X changed value from 1 to 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, violations := compareLock(&Catalog{Errors: tt.lock}, &Catalog{Errors: tt.current}, tt.types)
+
+			var locked []string
+			for _, e := range updated.Errors {
+				locked = append(locked, e.key())
+			}
+			if !reflect.DeepEqual(locked, tt.locked) {
+				t.Errorf("locked %v, want %v", locked, tt.locked)
+			}
+
+			if len(violations) != len(tt.violations) {
+				t.Fatalf("violations %q, want %d of them", violations, len(tt.violations))
+			}
+			for i, v := range violations {
+				if !strings.Contains(v, tt.violations[i]) {
+					t.Errorf("violation %q, want it to contain %q", v, tt.violations[i])
+				}
+			}
+		})
+	}
+}
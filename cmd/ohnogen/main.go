@@ -55,22 +55,57 @@
 //	Usage of ohnogen:
 //		ohnogen [flags] -type T [directory]
 //		ohnogen [flags] -type T files... # Must be a single package
+//		ohnogen catalog [flags] -type T [directory]
+//		ohnogen diff [flags] old.json new.json
+//		ohnogen fromcatalog [flags] [-type T] catalog.yaml
+//		ohnogen ts [flags] -type T [directory]
+//		ohnogen proto [flags] -type T [directory]
+//		ohnogen openapi [flags] -type T [directory]
 //
 // # Flags
 //
 //	Flags:
+//	  -docurl template
+//	    	template of the documentation URL of each constant returned by the generated DocURL method,
+//	    	e.g. https://docs.example.com/errors/{{.Package}}/{{.Name}}
+//	  -format string
+//	    	output format of the catalog mode, one of json (default), yaml, markdown, html
+//	    	or of the diff mode, one of text (default), markdown
+//	    	or of the openapi mode, one of yaml (default), json
 //	  -formatbase int
 //	    	format in which the enum value needs to be printed in different use cases.
 //	    	Valid options are 2(binary), 8(octal),10(decimal), 16(hex).
 //	    	default -formatbase=10 (default 10)
+//	  -locales directory
+//	    	directory holding <lang>.yaml files with the descriptions of the constants in other languages
+//	  -lock
+//	    	check the constants against the lock file and fail on incompatible changes, then update it
+//	  -lockfile string
+//	    	lock file name; default srcdir/errors.lock
 //	  -ohno
 //	    	generate the OhNo method for using with ohno package
+//	  -options
+//	    	generate the With method taking ohno.Option values for using with ohno package
 //	  -output string
 //	    	output file name; default srcdir/<type>_errors.go
+//	  -parse
+//	    	generate the Parse function returning the constant with a given name
+//	  -protopackage string
+//	    	package of the generated .proto file in the proto mode; default the Go package name
+//	  -protozero string
+//	    	handling of the zero value in the proto mode, one of
+//	    	reserve (a T_UNSPECIFIED = 0 value is added and no constant may be 0) or
+//	    	allow (a constant with the value 0 is used as the zero value) (default "reserve")
+//	  -strict
+//	    	fail on ambiguous names: constants sharing a value which are not deprecated, deprecated parents
 //	  -tags string
 //	    	comma-separated list of build tags to apply
+//	  -tests
+//	    	also look for the types in _test.go files and write the output to a _test.go file
 //	  -trimprefix prefix
 //	    	trim the prefix from the generated constant names
+//	  -tsenum
+//	    	declare the codes as a const enum instead of an object in the ts mode
 //	  -type string
 //	    	comma-separated list of type names; must be set
 //	  -version
@@ -86,6 +121,9 @@
 //	 	func (t T) Package() string
 //	 	func (t T) Code() string
 //
+//		// This function gets generated only if -parse flag is set
+//		func ParseT(name string) (T, bool)
+//
 //		// This function gets generated only if -ohno flag is set
 //		func (MyError) OhNo(message string, extra any, cause error,
 //			sourceInfoType sourceinfo.SourceInfoType, timestamp time.Time,
@@ -124,6 +162,9 @@
 //	func (MyError) Package() string
 //	func (MyError) Code() string
 //
+//	// This function gets generated only if -parse flag is set
+//	func ParseMyError(name string) (MyError, bool)
+//
 //	// This function gets generated only if -ohno flag is set
 //	func (MyError) OhNo(message string, extra any, cause error,
 //		sourceInfoType sourceinfo.SourceInfoType, timestamp time.Time,
@@ -189,6 +230,17 @@
 //
 //	0x0
 //
+// # Parse function
+//
+// The ParseMyError function, generated when the -parse flag is set, is the
+// inverse of the String() method, it returns the constant with the given name
+// along with true, or false if there is no such constant. The names of all the constants sharing a value and the names
+// listed in //ohno:alias annotations are accepted as well, so the call
+//
+//	somepkg.ParseMyError("Unknown")
+//
+// returns somepkg.Internal, true.
+//
 // # OhNo(...) method
 //
 // The OhNo(...) method constructs an [github.com/A-0-5/ohno/pkg/ohno.OhNoError] from the [github.com/A-0-5/ohno/pkg/ohno] package and
@@ -208,8 +260,9 @@
 //
 //	//go:generate ohnogen -type=MyError -ohno
 //
-// If multiple constants have the same value, the lexically first matching name will
-// be used (in the example, Unknown will print as "Internal").
+// If multiple constants have the same value, the first matching name which is
+// not deprecated will be used (in the example, Unknown will print as
+// "Internal").
 //
 // With no arguments, it processes the package in the current directory.
 // Otherwise, the arguments must name a single directory holding a Go package
@@ -227,6 +280,358 @@
 // timestamp, custom message etc. refer the [ohno] package for more details or
 // refer [examples] to see how to use them
 //
+// # The `-options` Flag
+//
+// The OhNo method needs every argument even when only some of them matter.
+// When this flag is set the method
+//
+//	func (MyError) With(opts ...ohno.Option) (ohnoError error)
+//
+// is generated as well, which only takes the parts that are set, using the
+// options of the [ohno] package
+//
+//	err := somepkg.NotFound.With(
+//		ohno.WithMessage("not_found message"),
+//		ohno.WithCause(cause),
+//		ohno.WithSource(sourceinfo.ShortFileAndLine),
+//	)
+//
+// The options are WithMessage, WithCause, WithExtra, WithSource and
+// WithTimestamp. The flag can be combined with -ohno or used on its own.
+//
+// # Annotations
+//
+// Additional information can be attached to a constant with directives of the
+// form //ohno:key=value in its doc comment, one per line. A directive without
+// a value like //ohno:key is the same as //ohno:key=true.
+//
+//	const (
+//		//ohno:owner=storage-team
+//		NotFound MyError = iota // Requested resource was not found
+//	)
+//
+// # Parent Codes
+//
+// Fine grained codes can be made to match a more generic code of the same type
+// by annotating them with //ohno:parent=Name.
+//
+//	const (
+//		NotFound MyError = iota // Requested resource was not found
+//		//ohno:parent=NotFound
+//		UserNotFound // User was not found
+//		//ohno:parent=UserNotFound
+//		DeletedUserNotFound // User was deleted
+//	)
+//
+// If any constant of a type has a parent the methods
+//
+//	func (MyError) Parent() (MyError, bool)
+//	func (MyError) Is(target error) bool
+//
+// are generated as well. Is reports whether the target is the code itself or
+// any of its parents, so errors.Is(err, NotFound) holds for an error
+// carrying DeletedUserNotFound, including an
+// [github.com/A-0-5/ohno/pkg/ohno.OhNoError] since it compares its error code
+// with errors.Is. Parents must be constants of the same type and must not
+// form a cycle.
+//
+// # Severity
+//
+// Constants can be given one of the severities of
+// [github.com/A-0-5/ohno/pkg/ohno.Severity] by its name, one of debug, info,
+// warning, error or critical.
+//
+//	const (
+//		//ohno:severity=info
+//		NotFound MyError = iota // Requested resource was not found
+//		//ohno:severity=critical
+//		Internal // An internal error occurred
+//	)
+//
+// If any constant of a type has a severity the method
+//
+//	func (MyError) Severity() ohno.Severity
+//
+// is generated as well, it returns ohno.SeverityUnspecified for the constants
+// without one. The severity is included when the error is marshaled and
+// [github.com/A-0-5/ohno/pkg/ohno.MaxSeverity] finds the highest severity in
+// a tree of errors.
+//
+// # Retryable Codes
+//
+// Constants of errors which may go away when the failed operation is tried
+// again can be annotated with //ohno:retryable.
+//
+//	const (
+//		NotFound MyError = iota // Requested resource was not found
+//		//ohno:retryable
+//		Timeout // Operation timed out
+//	)
+//
+// If any constant of a type is annotated the method
+//
+//	func (MyError) Retryable() bool
+//
+// is generated as well. It is used by
+// [github.com/A-0-5/ohno/pkg/ohno.IsRetryable] and
+// [github.com/A-0-5/ohno/pkg/ohno.Retry] to decide whether an error is worth
+// retrying.
+//
+// # Documentation URLs
+//
+// When -docurl is set to a [text/template] like
+//
+//	ohnogen -type=MyError -docurl='https://docs.example.com/errors/{{.Package}}/{{.Name}}'
+//
+// the method
+//
+//	func (MyError) DocURL() string
+//
+// is generated as well, returning the template executed for each constant
+// with the fields Package, Type, Name (as returned by String()), Identifier
+// (the name of the Go constant), Code (as returned by Code()) and Value (in
+// decimal). A single constant can be given a different template with
+// //ohno:docurl=template, which also makes the method be generated when
+// -docurl is not set. When an [github.com/A-0-5/ohno/pkg/ohno.OhNoError] with
+// such a code is marshaled the URL is included in the help field. Since go
+// generate only unquotes whole words, the whole flag is quoted in a
+// //go:generate directive
+//
+//	//go:generate ohnogen -type=MyError "-docurl=https://docs.example.com/errors/{{.Name}}"
+//
+// # Hints
+//
+// What can be done about an error can be given after the description in the
+// line comment, separated by a | character
+//
+//	const (
+//		TokenExpired MyError = iota // Token expired | hint: log in again
+//	)
+//
+// or with an //ohno:hint=text annotation, which takes precedence. If any
+// constant of a type has a hint the method
+//
+//	func (MyError) Hint() string
+//
+// is generated as well. The hint is printed and marshaled along with an
+// [github.com/A-0-5/ohno/pkg/ohno.OhNoError] and listed in the catalog.
+//
+// # Localized Descriptions
+//
+// Descriptions in other languages can be given with annotations named after
+// a BCP 47 language tag
+//
+//	const (
+//		//ohno:desc.de=Die angeforderte Ressource wurde nicht gefunden
+//		//ohno:desc.fr=La ressource demandée est introuvable
+//		NotFound MyError = iota // Requested resource was not found
+//	)
+//
+// or kept apart from the code in a directory given with -locales, holding a
+// file per language like de.yaml mapping the types to the names of their
+// constants (as returned by String()) and their descriptions
+//
+//	MyError:
+//	  NotFound: Die angeforderte Ressource wurde nicht gefunden
+//
+// The annotations take precedence over the files. If any description of a
+// type has been translated the method
+//
+//	func (MyError) LocalizedDescription(lang string) string
+//
+// is generated as well. It returns the description in the language lang, or
+// in its base language (de for de-CH) if there is none, and otherwise the
+// description returned by Description().
+// [github.com/A-0-5/ohno/pkg/ohno.Localize] uses it to print a whole error in
+// another language.
+//
+// # Message Templates
+//
+// A description can have placeholders in the syntax of [text/template]
+//
+//	const (
+//		//ohno:param.Attempts=int
+//		UserNotFound MyError = iota // user {{.UserID}} not found in {{.Region}} after {{.Attempts}} attempts
+//	)
+//
+// When -ohno is set a constructor taking an argument per placeholder is
+// generated for each such constant, named after it
+//
+//	func UserNotFoundErr(userID string, region string, attempts int) error
+//
+// The arguments are strings unless a type is given with an
+// //ohno:param.Name=type annotation. The returned
+// [github.com/A-0-5/ohno/pkg/ohno.OhNoError] keeps the description as the
+// message template and the arguments apart, so they are marshaled as
+// structured fields, and prints the filled in template in place of the
+// description.
+//
+// # Deprecated Codes and Aliases
+//
+// When a constant is renamed the old name can be kept as a constant sharing
+// the value and annotated //ohno:deprecated, or dropped and listed in an
+// //ohno:alias annotation of the new constant (several names are separated
+// by commas).
+//
+//	const (
+//		NotFound MyError = iota // Requested resource was not found
+//		//ohno:deprecated=use NotFound
+//		Missing = NotFound // Requested resource was not found
+//		//ohno:alias=Kaput,Broken
+//		Internal // An internal error occurred
+//	)
+//
+// String() returns the name of the first constant of a value which is not
+// deprecated, and ParseMyError (see -parse) accepts every name. If any
+// constant of the type is annotated //ohno:deprecated the method
+//
+//	func (MyError) Deprecated() bool
+//
+// is generated, it is true for the values all of whose constants are
+// deprecated. If any value has more than one name the method
+//
+//	func (MyError) Aliases() []string
+//
+// is generated, it returns the names other than the one returned by String().
+// Catalogs mark the deprecated constants and list their aliases.
+//
+// With -strict the generation fails, without writing anything, when several
+// constants sharing a value are not deprecated, since only one of their names
+// can be printed, or when a constant which is not deprecated has a deprecated
+// parent. This holds in every mode reading the constants from source.
+//
+// # Catalog Mode
+//
+// Running
+//
+//	ohnogen catalog -type=MyError -formatbase=16 -format=json
+//
+// does not generate any code, instead it writes a catalog of every constant of
+// the listed types to the standard output (or the file given with -output).
+// Each entry holds the package, type, name, code in the configured base,
+// description, annotations and the file and line where the constant is
+// declared. The -format flag selects between the machine readable json and
+// yaml formats and the human readable markdown and html tables.
+//
+// # Diff Mode
+//
+// Running
+//
+//	ohnogen diff -format=markdown old.json new.json
+//
+// compares two catalogs (json or yaml, as written by the catalog mode) and
+// writes a changelog of the error codes to the standard output (or the file
+// given with -output), suitable for release notes. The changes are grouped
+// into added, removed, renamed, renumbered, re-described and deprecated
+// codes. Removing a code which was not deprecated, renaming a code and
+// changing the value of a name are breaking changes, if there are any the
+// exit status is 1 so that release pipelines can stop.
+//
+// # Fromcatalog Mode
+//
+// The catalog can also be the single source of truth instead of the Go
+// source. Running
+//
+//	ohnogen fromcatalog -formatbase=16 -ohno errors.yaml
+//
+// reads a catalog (json or yaml, in the format written by the catalog mode)
+// and generates a file containing the type declaration, the const block with
+// the descriptions as line comments and the annotations as directives, along
+// with all the usual methods. Only the package, type, identifier, value,
+// description and annotations of the entries are used. All the entries must
+// belong to the same package, -type restricts the generation to some of the
+// types in the catalog. The default output file is t_errors.go in the current
+// directory, where t is the lower-cased name of the first type.
+//
+// # TS Mode
+//
+// Running
+//
+//	ohnogen ts -type=MyError -formatbase=16
+//
+// writes a TypeScript module to myerror_errors.ts (or the file given with
+// -output) so that frontends can switch on codes which are guaranteed to
+// match the Go side. For each type it declares an object of the values
+// (declared as const, or a const enum with -tsenum) with the matching union
+// type, lookups of the name, code and description of each value, a
+// describeT(value) function and a union type T + "JSON" of the shape produced
+// when an [github.com/A-0-5/ohno/pkg/ohno.OhNoError] carrying one of the codes
+// is marshaled to json, discriminated on its name.
+//
+// # Proto Mode
+//
+// Running
+//
+//	ohnogen proto -type=MyError -trimprefix=Err
+//
+// writes a proto3 file to myerror_errors.proto (or the file given with
+// -output) declaring an enum for each type, so that the error reasons of gRPC
+// APIs come from the same declaration as the Go constants. The values follow
+// the proto naming rules: the name (with the prefix trimmed) is converted to
+// upper snake case and prefixed with the upper snake case type name, so
+// NotFound of MyError becomes MY_ERROR_NOT_FOUND. Descriptions become
+// comments, constants annotated //ohno:deprecated are marked deprecated and
+// allow_alias is set when constants share a value.
+//
+// proto3 requires the first value of an enum to be 0. With the default
+// -protozero=reserve a MY_ERROR_UNSPECIFIED = 0 value is added and it is an
+// error for a constant to have the value 0, so that an unset field never
+// reads as a real error. With -protozero=allow a constant with the value 0 is
+// used as the zero value, and MY_ERROR_UNSPECIFIED is only added if there is
+// none.
+//
+// # Openapi Mode
+//
+// Running
+//
+//	ohnogen openapi -type=MyError -formatbase=16
+//
+// writes an OpenAPI 3.1 document to myerror_errors.openapi.yaml (or the file
+// given with -output, -format=json writes json instead) holding only
+// components, meant to be referenced from the specs of HTTP APIs. It declares
+// the schemas
+//
+//   - OhNoError, the json object produced by marshaling an
+//     [github.com/A-0-5/ohno/pkg/ohno.OhNoError]
+//   - OhNoJoinError, OhNoCause and OhNoSourceInformation used by it, a cause
+//     being either an OhNoError, an OhNoJoinError or the message of any other
+//     error
+//   - MyErrorCode and MyErrorName, the codes and names of MyError each with its
+//     description
+//   - MyError, an OhNoError whose package, code and name are those of MyError
+//
+// so a response can be documented with
+//
+//	$ref: 'myerror_errors.openapi.yaml#/components/schemas/MyError'
+//
+// # The `-lock` Flag
+//
+// Clients often persist error codes, so renumbering or removing a constant
+// silently breaks them. When -lock is set the constants are compared against
+// the catalog stored in errors.lock (or the file given with -lockfile) before
+// anything is written, and ohnogen fails with a report of every incompatible
+// change when
+//
+//   - an existing name changes its value
+//   - a new name reuses the value of a name which no longer exists
+//   - a constant disappears without having been annotated //ohno:deprecated
+//
+// Otherwise the lock file is updated (or created) with the current constants.
+// Constants which were deprecated and then removed are kept in the lock file
+// as retired so that their codes are never handed out again. The lock file is
+// in the json catalog format and is meant to be committed.
+//
+// # The `-tests` Flag
+//
+// By default only the non-test files of a package are looked at. Enums which
+// exist only in test files (fixtures, fake backends etc.) can be generated by
+// setting this flag. The type may then be declared either in a _test.go file
+// of the package itself or in its external _test package, and the generated
+// methods are written to t_errors_test.go in the matching package so that they
+// are compiled only with the tests. When combined with -output the generated
+// Go file name must end with _test.go, the outputs of the other modes are not
+// affected.
+//
 // # Examples
 //
 // You can use this tool in multiple ways. Checkout the [examples] part of this
@@ -252,6 +657,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"strings"
+	"text/template"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -261,16 +667,60 @@ var (
 	output       = flag.String("output", "", "output file name; default srcdir/<type>_errors.go")
 	trimprefix   = flag.String("trimprefix", "", "trim the `prefix` from the generated constant names")
 	ohnoFlag     = flag.Bool("ohno", false, "generate the OhNo method for using with ohno package")
+	optionsFlag  = flag.Bool("options", false, "generate the With method taking ohno.Option values for using with ohno package")
+	parseFlag    = flag.Bool("parse", false, "generate the Parse function returning the constant with a given name")
 	codeBaseFlag = flag.Int("formatbase", 10, "format in which the enum value needs to be printed in different use cases.\nValid options are 2(binary), 8(octal),10(decimal), 16(hex).\ndefault -formatbase=10")
 	buildTags    = flag.String("tags", "", "comma-separated list of build tags to apply")
+	testsFlag    = flag.Bool("tests", false, "also look for the types in _test.go files and write the output to a _test.go file")
+	formatFlag   = flag.String("format", "", "output format of the catalog mode, one of json (default), yaml, markdown, html\nor of the diff mode, one of text (default), markdown\nor of the openapi mode, one of yaml (default), json")
+	lockFlag     = flag.Bool("lock", false, "check the constants against the lock file and fail on incompatible changes, then update it")
+	lockFile     = flag.String("lockfile", "", "lock file name; default srcdir/errors.lock")
+	tsEnumFlag   = flag.Bool("tsenum", false, "declare the codes as a const enum instead of an object in the ts mode")
+	protoPackage = flag.String("protopackage", "", "package of the generated .proto file in the proto mode; default the Go package name")
+	protoZero    = flag.String("protozero", protoZeroReserve, "handling of the zero value in the proto mode, one of\nreserve (a T_UNSPECIFIED = 0 value is added and no constant may be 0) or\nallow (a constant with the value 0 is used as the zero value)")
+	localesFlag  = flag.String("locales", "", "`directory` holding <lang>.yaml files with the descriptions of the constants in other languages")
+	docURLFlag   = flag.String("docurl", "", "`template` of the documentation URL of each constant returned by the generated DocURL method,\ne.g. https://docs.example.com/errors/{{.Package}}/{{.Name}}")
+	strictFlag   = flag.Bool("strict", false, "fail on ambiguous names: constants sharing a value which are not deprecated, deprecated parents")
 	versionInfo  = flag.Bool("version", false, "prints the current version information of this tool")
 )
 
+// Modes of operation other than the default generation of methods. The mode
+// is selected by the first argument.
+const (
+	// Write a catalog of the error constants
+	modeCatalog = "catalog"
+	// Compare two catalogs
+	modeDiff = "diff"
+	// Generate the constants and their methods from a catalog
+	modeFromCatalog = "fromcatalog"
+	// Write a TypeScript module of the error constants
+	modeTypeScript = "ts"
+	// Write a Protocol Buffers enum of the error constants
+	modeProto = "proto"
+	// Write OpenAPI components describing the errors
+	modeOpenAPI = "openapi"
+)
+
+// isMode reports whether arg names one of the modes.
+func isMode(arg string) bool {
+	switch arg {
+	case modeCatalog, modeDiff, modeFromCatalog, modeTypeScript, modeProto, modeOpenAPI:
+		return true
+	}
+	return false
+}
+
 // Usage is a replacement usage function for the flags package.
 func Usage() {
 	fmt.Fprintf(os.Stderr, "Usage of ohnogen:\n")
 	fmt.Fprintf(os.Stderr, "\tohnogen [flags] -type T [directory]\n")
 	fmt.Fprintf(os.Stderr, "\tohnogen [flags] -type T files... # Must be a single package\n")
+	fmt.Fprintf(os.Stderr, "\tohnogen catalog [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "\tohnogen diff [flags] old.json new.json\n")
+	fmt.Fprintf(os.Stderr, "\tohnogen fromcatalog [flags] [-type T] catalog.yaml\n")
+	fmt.Fprintf(os.Stderr, "\tohnogen ts [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "\tohnogen proto [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "\tohnogen openapi [flags] -type T [directory]\n")
 	fmt.Fprintf(os.Stderr, "For more information, see:\n")
 	fmt.Fprintf(os.Stderr, "\thttps://pkg.go.dev/github.com/A-0-5/ohno/cmd/ohnogen\n")
 	fmt.Fprintf(os.Stderr, "Flags:\n")
@@ -281,7 +731,16 @@ func main() {
 	log.SetFlags(0)
 	log.SetPrefix("ohnogen: ")
 	flag.Usage = Usage
-	flag.Parse()
+
+	// The first argument may select a mode other than the default generation
+	// of methods, the remaining arguments are the usual flags.
+	mode := ""
+	arguments := os.Args[1:]
+	if len(arguments) > 0 && isMode(arguments[0]) {
+		mode = arguments[0]
+		arguments = arguments[1:]
+	}
+	flag.CommandLine.Parse(arguments)
 	if *versionInfo {
 		info, ok := debug.ReadBuildInfo()
 		if !ok {
@@ -291,39 +750,46 @@ func main() {
 		fmt.Fprintf(os.Stdout, "ohnogen\n-------\nversion : %s\nsum     : %s\n", info.Main.Version, info.Main.Sum)
 		os.Exit(0)
 	}
-	if len(*typeNames) == 0 {
+	if mode == modeDiff {
+		if flag.NArg() != 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		os.Exit(diffCatalogs(flag.Arg(0), flag.Arg(1), *formatFlag, *output))
+	}
+	if len(*typeNames) == 0 && mode != modeFromCatalog {
 		flag.Usage()
 		os.Exit(2)
 	}
 
-	codeBasePrefixString := ""
+	codePrefix := ""
 	switch *codeBaseFlag {
 	case 2:
-		codeBasePrefixString = "\"0b\" + "
+		codePrefix = "0b"
 	case 8:
-		codeBasePrefixString = "\"0o\" + "
+		codePrefix = "0o"
 	case 10:
-		codeBasePrefixString = ""
+		codePrefix = ""
 	case 16:
-		codeBasePrefixString = "\"0x\" + "
+		codePrefix = "0x"
 	default:
 		log.Fatalf("formatbase can only be one of 2,8,10,16 current value = %d", *codeBaseFlag)
 	}
 
-	types := strings.Split(*typeNames, ",")
+	codeBasePrefixString := ""
+	if codePrefix != "" {
+		codeBasePrefixString = "\"" + codePrefix + "\" + "
+	}
+
+	var types []string
+	if len(*typeNames) > 0 {
+		types = strings.Split(*typeNames, ",")
+	}
 	var tags []string
 	if len(*buildTags) > 0 {
 		tags = strings.Split(*buildTags, ",")
 	}
 
-	// We accept either one directory or a list of files. Which do we have?
-	args := flag.Args()
-	if len(args) == 0 {
-		// Default: process whole package in current directory.
-		args = []string{"."}
-	}
-
-	// Parse the package once.
 	var dir string
 	g := Generator{
 		trimPrefix:     *trimprefix,
@@ -331,34 +797,39 @@ func main() {
 		ohnoEnable:     *ohnoFlag,
 		codeBase:       *codeBaseFlag,
 		codeBasePrefix: codeBasePrefixString,
+		codePrefix:     codePrefix,
+		tests:          *testsFlag,
+		strict:         *strictFlag,
+	}
+	if *docURLFlag != "" {
+		g.docURLTemplate = parseDocURL("docurl", *docURLFlag)
+	}
+	if *localesFlag != "" {
+		g.locales = readLocales(*localesFlag)
 	}
 
-	// TODO(suzmue): accept other patterns for packages (directories, list of files, import paths, etc).
-	if len(args) == 1 && isDirectory(args[0]) {
-		dir = args[0]
-	} else {
-		if len(tags) != 0 {
-			log.Fatal("-tags option applies only to directories, not when files are specified")
+	if mode == modeFromCatalog {
+		if flag.NArg() != 1 {
+			flag.Usage()
+			os.Exit(2)
 		}
-		dir = filepath.Dir(args[0])
+		dir = "."
+		types = g.generateFromCatalog(flag.Arg(0), types)
+	} else {
+		dir = g.generateFromSource(types, tags, mode)
 	}
 
-	g.parsePackage(args, tags)
-
-	// Print the header and package clause.
-	g.Printf("// Code generated by \"ohnogen %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
-	g.Printf("\n")
-	g.Printf("package %s", g.pkg.name)
-	g.Printf("\n")
-	if g.ohnoEnable {
-		g.Printf("import (\n\t\"strconv\"\n\t\"time\"\n\t\"github.com/A-0-5/ohno/pkg/ohno\"\n\t\"github.com/A-0-5/ohno/pkg/sourceinfo\"\n)\n")
-	} else {
-		g.Printf("import \"strconv\"\n") // Used by all methods.
+	if len(g.warnings) > 0 {
+		for _, w := range g.warnings {
+			log.Printf("warning: %s", w)
+		}
+		log.Fatalf("%d problems found with -strict, nothing was written", len(g.warnings))
 	}
 
-	// Run generate for each type.
-	for _, typeName := range types {
-		g.generate(typeName)
+	g.writeLock()
+	g.writeOutputs()
+	if dir == "" {
+		return
 	}
 
 	// Format the output.
@@ -368,6 +839,9 @@ func main() {
 	outputName := *output
 	if outputName == "" {
 		baseName := fmt.Sprintf("%s_errors.go", types[0])
+		if g.tests {
+			baseName = fmt.Sprintf("%s_errors_test.go", types[0])
+		}
 		outputName = filepath.Join(dir, strings.ToLower(baseName))
 	}
 	err := os.WriteFile(outputName, src, 0644)
@@ -376,6 +850,168 @@ func main() {
 	}
 }
 
+// generateFromSource parses the package named by the command line arguments
+// and generates the methods for the named types. It returns the directory of
+// the package, or an empty string if the mode does not produce any code.
+func (g *Generator) generateFromSource(types []string, tags []string, mode string) (dir string) {
+	// We accept either one directory or a list of files. Which do we have?
+	args := flag.Args()
+	if len(args) == 0 {
+		// Default: process whole package in current directory.
+		args = []string{"."}
+	}
+
+	// TODO(suzmue): accept other patterns for packages (directories, list of files, import paths, etc).
+	if len(args) == 1 && isDirectory(args[0]) {
+		dir = args[0]
+	} else {
+		if len(tags) != 0 {
+			log.Fatal("-tags option applies only to directories, not when files are specified")
+		}
+		dir = filepath.Dir(args[0])
+	}
+
+	g.parsePackage(args, tags, types[0])
+
+	if *lockFlag {
+		lockName := *lockFile
+		if lockName == "" {
+			lockName = filepath.Join(dir, defaultLockFile)
+		}
+		g.checkLock(types, lockName)
+	}
+
+	// The other modes do not generate methods, the constants are still
+	// checked so that -strict fails the same way.
+	if mode != "" {
+		for _, typeName := range types {
+			values := g.values(typeName)
+			g.checkStrict(typeName, namesByValue(typeName, values), parents(typeName, values))
+		}
+	}
+
+	switch mode {
+	case modeCatalog:
+		g.addOutput(*output, formatCatalog(g.catalog(types), *formatFlag))
+		return ""
+	case modeTypeScript:
+		g.addOutput(defaultOutput(*output, dir, types[0], ".ts"), typeScript(g.catalog(types), *tsEnumFlag))
+		return ""
+	case modeProto:
+		protoPkg := *protoPackage
+		if protoPkg == "" {
+			protoPkg = g.pkg.name
+		}
+		g.addOutput(defaultOutput(*output, dir, types[0], ".proto"), proto(g.catalog(types), protoPkg, *protoZero))
+		return ""
+	case modeOpenAPI:
+		format := *formatFlag
+		if format == "" {
+			format = "yaml"
+		}
+		g.addOutput(defaultOutput(*output, dir, types[0], ".openapi."+format), openAPI(g.catalog(types), format))
+		return ""
+	}
+
+	if g.tests && *output != "" && !strings.HasSuffix(*output, "_test.go") {
+		log.Fatal("-output must name a _test.go file when -tests is set")
+	}
+
+	// Run generate for each type.
+	for _, typeName := range types {
+		g.generate(typeName, g.values(typeName))
+	}
+
+	return dir
+}
+
+// pendingOutput is a file written once the -strict checks passed.
+type pendingOutput struct {
+	name string // Empty for the standard output
+	data []byte
+}
+
+// addOutput records the file to write once the -strict checks passed, so that
+// nothing is written if they fail.
+func (g *Generator) addOutput(name string, data []byte) {
+	g.outputs = append(g.outputs, pendingOutput{name: name, data: data})
+}
+
+// writeOutputs writes the files recorded by addOutput.
+func (g *Generator) writeOutputs() {
+	for _, o := range g.outputs {
+		writeOutput(o.name, o.data)
+	}
+}
+
+// checkStrict records the problems found in the constants of the type when
+// -strict is set, they are reported before anything is written.
+func (g *Generator) checkStrict(typeName string, names []valueNames, links []parentLink) {
+	if g.strict {
+		g.warnings = append(g.warnings, strictWarnings(typeName, names, links)...)
+	}
+}
+
+// header returns the header, package clause and imports of the generated
+// file. It is built after the methods so that it holds the imports they need.
+func (g *Generator) header() []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by \"ohnogen %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(&b, "\n")
+	fmt.Fprintf(&b, "package %s", g.pkg.name)
+	fmt.Fprintf(&b, "\n")
+
+	used := map[string]bool{"strconv": true} // Used by all methods.
+	if g.ohnoEnable {
+		used["time"] = true
+		used[ohnoImport] = true
+		used[sourceinfoImport] = true
+	}
+	for path := range g.imports {
+		used[path] = true
+	}
+	imports := make([]string, 0, len(used))
+	for path := range used {
+		imports = append(imports, path)
+	}
+	sort.Strings(imports)
+
+	if len(imports) == 1 {
+		fmt.Fprintf(&b, "import %q\n", imports[0])
+		return b.Bytes()
+	}
+	fmt.Fprintf(&b, "import (\n")
+	for _, path := range imports {
+		fmt.Fprintf(&b, "\t%q\n", path)
+	}
+	fmt.Fprintf(&b, ")\n")
+	return b.Bytes()
+}
+
+// Import paths of the packages the generated code may use.
+const (
+	ohnoImport       = "github.com/A-0-5/ohno/pkg/ohno"
+	sourceinfoImport = "github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// addImport records that the generated code uses the package with the given
+// import path.
+func (g *Generator) addImport(path string) {
+	if g.imports == nil {
+		g.imports = make(map[string]bool)
+	}
+	g.imports[path] = true
+}
+
+// defaultOutput returns output if it is set, otherwise the default file name
+// for the type in dir with the given extension.
+func defaultOutput(output, dir, typeName, ext string) string {
+	if output != "" {
+		return output
+	}
+	return filepath.Join(dir, strings.ToLower(typeName+"_errors"+ext))
+}
+
 // isDirectory reports whether the named file is a directory.
 func isDirectory(name string) bool {
 	info, err := os.Stat(name)
@@ -388,14 +1024,24 @@ func isDirectory(name string) bool {
 // Generator holds the state of the analysis. Primarily used to buffer
 // the output for format.Source.
 type Generator struct {
-	buf bytes.Buffer // Accumulated output.
-	pkg *Package     // Package we are scanning.
+	buf     bytes.Buffer    // Accumulated output.
+	pkg     *Package        // Package we are scanning.
+	imports map[string]bool // Packages used by the output in addition to the default ones.
 
 	trimPrefix     string
 	lineComment    bool
 	ohnoEnable     bool
 	codeBase       int
 	codeBasePrefix string
+	codePrefix     string
+	tests          bool
+	strict         bool
+	docURLTemplate *template.Template // Parsed -docurl flag, nil if not set.
+	locales        locales            // Descriptions read from the -locales directory.
+	warnings       []string           // Problems found by the -strict checks.
+	lockName       string             // Lock file updated by -lock, empty if not set.
+	lockData       []byte             // Contents of the updated lock file.
+	outputs        []pendingOutput    // Files of the modes other than the default one.
 
 	logf func(format string, args ...interface{}) // test logging hook; nil when not testing
 }
@@ -419,35 +1065,94 @@ type File struct {
 
 type Package struct {
 	name  string
+	fset  *token.FileSet
 	defs  map[*ast.Ident]types.Object
 	files []*File
 }
 
 // parsePackage analyzes the single package constructed from the patterns and tags.
+// When tests are enabled the test variants of the package are loaded as well
+// and the one declaring typeName is used.
 // parsePackage exits if there is an error.
-func (g *Generator) parsePackage(patterns []string, tags []string) {
+func (g *Generator) parsePackage(patterns []string, tags []string, typeName string) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
-		// TODO: Need to think about constants in test files. Maybe write type_string_test.go
-		// in a separate pass? For later.
-		Tests:      false,
+		Mode:       packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedSyntax,
+		Tests:      g.tests,
 		BuildFlags: []string{fmt.Sprintf("-tags=%s", strings.Join(tags, " "))},
 		Logf:       g.logf,
 	}
+	if g.tests {
+		// The synthesized test main imports packages which need to be type
+		// checked as well, otherwise loading fails. The files tell the test
+		// variants apart.
+		cfg.Mode |= packages.NeedImports | packages.NeedDeps | packages.NeedFiles
+	}
 	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if g.tests {
+		pkgs = selectTestPackage(pkgs, typeName)
+	}
 	if len(pkgs) != 1 {
 		log.Fatalf("error: %d packages matching %v", len(pkgs), strings.Join(patterns, " "))
 	}
 	g.addPackage(pkgs[0])
 }
 
+// selectTestPackage picks the package declaring typeName out of the packages
+// loaded with tests enabled. For a single directory the loader returns the
+// package itself, its in-package test variant (which also holds the _test.go
+// files of the same package), the external _test package and the generated
+// test main. The variants are told apart by their files, those holding
+// _test.go files are preferred over the plain package so that types declared
+// in them are found.
+func selectTestPackage(pkgs []*packages.Package, typeName string) []*packages.Package {
+	var plain, variants []*packages.Package
+	for _, pkg := range pkgs {
+		switch {
+		case pkg.Name == "main" && strings.HasSuffix(pkg.PkgPath, ".test"):
+			// The synthesized test main package, never the one we want.
+		case hasTestFiles(pkg):
+			variants = append(variants, pkg)
+		default:
+			plain = append(plain, pkg)
+		}
+	}
+
+	var found []*packages.Package
+	for _, candidates := range [][]*packages.Package{variants, plain} {
+		for _, pkg := range candidates {
+			if pkg.Types == nil {
+				continue
+			}
+			if _, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName); ok {
+				found = append(found, pkg)
+			}
+		}
+		if len(found) > 0 {
+			return found
+		}
+	}
+
+	return plain
+}
+
+// hasTestFiles reports whether some of the files of pkg are _test.go files.
+func hasTestFiles(pkg *packages.Package) bool {
+	for _, file := range pkg.GoFiles {
+		if strings.HasSuffix(file, "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
 // addPackage adds a type checked Package and its syntax files to the generator.
 func (g *Generator) addPackage(pkg *packages.Package) {
 	g.pkg = &Package{
 		name:  pkg.Name,
+		fset:  pkg.Fset,
 		defs:  pkg.TypesInfo.Defs,
 		files: make([]*File, len(pkg.Syntax)),
 	}
@@ -463,8 +1168,9 @@ func (g *Generator) addPackage(pkg *packages.Package) {
 	}
 }
 
-// generate produces the String method for the named type.
-func (g *Generator) generate(typeName string) {
+// values returns all the constants declared for the named type in the order
+// in which they appear in the package.
+func (g *Generator) values(typeName string) []Value {
 	values := make([]Value, 0, 100)
 	for _, file := range g.pkg.files {
 		// Set the state for this run of the walker.
@@ -480,6 +1186,12 @@ func (g *Generator) generate(typeName string) {
 		log.Fatalf("no values defined for type %s", typeName)
 	}
 
+	return values
+}
+
+// generate produces the String method for the named type.
+func (g *Generator) generate(typeName string, values []Value) {
+
 	signed := values[0].signed
 	// Generate code that will fail if the constants change value.
 	g.Printf("func _() {\n")
@@ -490,6 +1202,12 @@ func (g *Generator) generate(typeName string) {
 		g.Printf("\t_ = x[%s - %s]\n", v.originalName, v.str)
 	}
 	g.Printf("}\n")
+	// The parents need every constant, splitIntoRuns drops the duplicates.
+	links := parents(typeName, values)
+	names := namesByValue(typeName, values)
+	g.checkStrict(typeName, names, links)
+	severities := severities(typeName, values)
+	retryable, hasRetryable := flagged(values, retryableAnnotation)
 	runs := splitIntoRuns(values)
 	// The decision of which pattern to use depends on the number of
 	// runs in the numbers. If there's only one, it's easy. For more than
@@ -525,10 +1243,30 @@ func (g *Generator) generate(typeName string) {
 	}
 
 	g.Printf(codeFunc, typeName, formatInt, typeCast, g.codeBase, g.codeBasePrefix)
+	g.buildNames(typeName, names)
+	g.buildDocURL(typeName, names)
+	g.buildHint(typeName, names)
+	g.buildLocalized(typeName, names)
+	g.buildConstructors(typeName, names)
+	if len(links) > 0 {
+		g.buildParent(typeName, links)
+	}
+	if len(severities) > 0 {
+		g.buildSeverity(typeName, severities)
+	}
+	if hasRetryable {
+		g.buildFlag(typeName, "Retryable", "Reports whether the operation which failed with the error code may succeed when retried", retryable)
+	}
 	if *ohnoFlag {
 		g.Printf("\n")
 		g.Printf(ohNoFunc, typeName, g.pkg.name)
 	}
+	if *optionsFlag {
+		g.addImport(ohnoImport)
+		g.addImport(sourceinfoImport)
+		g.Printf("\n")
+		g.Printf(withFunc, typeName)
+	}
 }
 
 // splitIntoRuns breaks the values into runs of contiguous sequences.
@@ -537,17 +1275,20 @@ func (g *Generator) generate(typeName string) {
 func splitIntoRuns(values []Value) [][]Value {
 	// We use stable sort so the lexically first name is chosen for equal elements.
 	sort.Stable(byValue(values))
-	// Remove duplicates. Stable sort has put the one we want to print first,
-	// so use that one. The String method won't care about which named constant
-	// was the argument, so the first name for the given value is the only one to keep.
+	// Remove duplicates. The String method won't care about which named
+	// constant was the argument, so only the name we want to print is kept,
+	// which is the first one that is not deprecated.
 	// We need to do this because identical values would cause the switch or map
 	// to fail to compile.
-	j := 1
-	for i := 1; i < len(values); i++ {
-		if values[i].value != values[i-1].value {
-			values[j] = values[i]
-			j++
+	j := 0
+	for i := 0; i < len(values); {
+		k := i + 1
+		for k < len(values) && values[k].value == values[i].value {
+			k++
 		}
+		values[j] = canonical(values[i:k])
+		j++
+		i = k
 	}
 	values = values[:j]
 	runs := make([][]Value, 0, 10)
@@ -563,15 +1304,17 @@ func splitIntoRuns(values []Value) [][]Value {
 	return runs
 }
 
-// format returns the gofmt-ed contents of the Generator's buffer.
+// format returns the gofmt-ed contents of the Generator's buffer, preceded by
+// the header.
 func (g *Generator) format() []byte {
-	src, err := format.Source(g.buf.Bytes())
+	raw := append(g.header(), g.buf.Bytes()...)
+	src, err := format.Source(raw)
 	if err != nil {
 		// Should never happen, but can arise when developing this code.
 		// The user can compile the output to see the error.
 		log.Printf("warning: internal error: invalid Go generated: %s", err)
 		log.Printf("warning: compile the package to analyze the error")
-		return g.buf.Bytes()
+		return raw
 	}
 	return src
 }
@@ -589,6 +1332,9 @@ type Value struct {
 	signed      bool   // Whether the constant is a signed type.
 	str         string // The string representation given by the "go/constant" package.
 	description string
+	hint        string            // What can be done about the error.
+	annotations map[string]string // The //ohno:key=value directives in the doc comment.
+	position    token.Position    // Where the constant is declared.
 }
 
 func (v *Value) String() string {
@@ -619,6 +1365,12 @@ func (f *File) genDecl(node ast.Node) bool {
 	// The name of the type of the constants we are declaring.
 	// Can change if this is a multi-element declaration.
 	typ := ""
+	// A doc comment of an ungrouped declaration is attached to the GenDecl
+	// rather than the ValueSpec.
+	declDoc := decl.Doc
+	if decl.Lparen.IsValid() {
+		declDoc = nil
+	}
 	// Loop over the elements of the declaration. Each element is a ValueSpec:
 	// a list of names possibly followed by a type, possibly followed by values.
 	// If the type and value are both missing, we carry down the type (and value,
@@ -628,22 +1380,26 @@ func (f *File) genDecl(node ast.Node) bool {
 		if vspec.Type == nil && len(vspec.Values) > 0 {
 			// "X = 1". With no type but a value. If the constant is untyped,
 			// skip this vspec and reset the remembered type.
-			typ = ""
-
-			// If this is a simple type conversion, remember the type.
-			// We don't mind if this is actually a call; a qualified call won't
-			// be matched (that will be SelectorExpr, not Ident), and only unusual
-			// situations will result in a function call that appears to be
-			// a type conversion.
-			ce, ok := vspec.Values[0].(*ast.CallExpr)
-			if !ok {
-				continue
+			// The type checker knows the type of typed constants, which also
+			// covers "X = Y" where Y is a constant of the type, like an alias
+			// kept after a rename.
+			typ = f.definedType(vspec)
+			if typ == "" {
+				// If this is a simple type conversion, remember the type.
+				// We don't mind if this is actually a call; a qualified call won't
+				// be matched (that will be SelectorExpr, not Ident), and only unusual
+				// situations will result in a function call that appears to be
+				// a type conversion.
+				ce, ok := vspec.Values[0].(*ast.CallExpr)
+				if !ok {
+					continue
+				}
+				id, ok := ce.Fun.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				typ = id.Name
 			}
-			id, ok := ce.Fun.(*ast.Ident)
-			if !ok {
-				continue
-			}
-			typ = id.Name
 		}
 		if vspec.Type != nil {
 			// "X T". We have a type. Remember it.
@@ -660,6 +1416,10 @@ func (f *File) genDecl(node ast.Node) bool {
 		// We now have a list of names (from one line of source code) all being
 		// declared with the desired type.
 		// Grab their names and actual values and store them in f.values.
+		doc := vspec.Doc
+		if doc == nil {
+			doc = declDoc
+		}
 		for _, name := range vspec.Names {
 			if name.Name == "_" {
 				continue
@@ -692,9 +1452,14 @@ func (f *File) genDecl(node ast.Node) bool {
 				value:        u64,
 				signed:       info&types.IsUnsigned == 0,
 				str:          value.String(),
+				annotations:  parseAnnotations(doc),
+				position:     f.pkg.fset.Position(name.Pos()),
 			}
 			if c := vspec.Comment; f.lineComment && c != nil && len(c.List) == 1 {
-				v.description = strings.TrimSpace(c.Text())
+				v.description, v.hint = splitHint(strings.TrimSpace(c.Text()))
+			}
+			if hint, ok := v.annotations[hintAnnotation]; ok {
+				v.hint = hint
 			}
 
 			v.name = strings.TrimPrefix(v.originalName, f.trimPrefix)
@@ -705,6 +1470,62 @@ func (f *File) genDecl(node ast.Node) bool {
 	return false
 }
 
+// definedType returns the name of the type of the constants declared by vspec
+// if it is a named type of the package, otherwise an empty string.
+func (f *File) definedType(vspec *ast.ValueSpec) string {
+	if len(vspec.Names) == 0 || f.pkg.defs == nil {
+		return ""
+	}
+	obj, ok := f.pkg.defs[vspec.Names[0]]
+	if !ok || obj == nil {
+		return ""
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.Obj().Pkg() != obj.Pkg() {
+		return ""
+	}
+	return named.Obj().Name()
+}
+
+// annotationPrefix marks the directives in the doc comment of a constant
+// which attach additional information to it.
+const annotationPrefix = "//ohno:"
+
+// parseAnnotations collects the annotations of a constant from its doc
+// comment. Each annotation is a directive on its own line in the form
+//
+//	//ohno:key=value
+//
+// A directive without a value, like //ohno:key, is recorded as "true".
+// It returns nil when there are no annotations.
+func parseAnnotations(doc *ast.CommentGroup) map[string]string {
+	if doc == nil {
+		return nil
+	}
+
+	var annotations map[string]string
+	for _, c := range doc.List {
+		if !strings.HasPrefix(c.Text, annotationPrefix) {
+			continue
+		}
+		key, value, found := strings.Cut(strings.TrimPrefix(c.Text, annotationPrefix), "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			log.Fatalf("empty annotation key in %q", c.Text)
+		}
+		value = strings.TrimSpace(value)
+		if !found {
+			value = "true"
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[key] = value
+	}
+
+	return annotations
+}
+
 // Helpers
 
 // usize returns the number of bits of the smallest unsigned integer
@@ -1027,6 +1848,18 @@ func (i %[1]s) Code() string {
 }
 `
 
+// Arguments to format are:
+//
+//	[1]: type name
+const withFunc = `// Generate a new error of [ohno.OhNoError] type with the parts set by the
+// options, anything not set is left empty. For example
+//
+//	err := NotFound.With(ohno.WithMessage("no such user"), ohno.WithCause(cause))
+func (i %[1]s) With(opts ...ohno.Option) error {
+	return ohno.NewWithOptions(i, sourceinfo.DefaultCallDepth+1, opts...)
+}
+`
+
 const ohNoFunc = `// Generate a new error of [ohno.OhNoError] type with the data provided
 // timestamp is optional, empty [timestampLayout] will assume default timestamp 
 // of RFC3339Nano,  if you do not want source information to be captured pass 
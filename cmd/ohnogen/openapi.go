@@ -0,0 +1,143 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaRefPrefix is the prefix of references to the generated schemas.
+const schemaRefPrefix = "#/components/schemas/"
+
+// openAPISchema is the subset of an OpenAPI 3.1 (JSON Schema 2020-12) schema
+// object used by the generated components.
+type openAPISchema struct {
+	Ref         string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Title       string                    `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Type        any                       `json:"type,omitempty" yaml:"type,omitempty"`
+	Const       string                    `json:"const,omitempty" yaml:"const,omitempty"`
+	Properties  map[string]*openAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	OneOf       []*openAPISchema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	AllOf       []*openAPISchema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
+}
+
+// openAPIDocument is an OpenAPI document holding only components.
+type openAPIDocument struct {
+	OpenAPI    string `json:"openapi" yaml:"openapi"`
+	Components struct {
+		Schemas map[string]any `json:"schemas" yaml:"schemas"`
+	} `json:"components" yaml:"components"`
+}
+
+// schemaRef returns a schema referring to the named component.
+func schemaRef(name string) *openAPISchema {
+	return &openAPISchema{Ref: schemaRefPrefix + name}
+}
+
+// ohNoErrorSchemas returns the schemas describing the json produced by
+// marshaling an ohno.OhNoError, taken from the definitions of the schema
+// shipped with the ohno package. They are yaml nodes, or decoded values when
+// asJSON is set.
+func ohNoErrorSchemas(asJSON bool) map[string]any {
+	schemas := make(map[string]any)
+	for _, d := range ohnoSchemaDefs() {
+		node := rewriteRefs(d.schema, func(def string) string {
+			return schemaRefPrefix + defName(def)
+		})
+		if !asJSON {
+			schemas[defName(d.name)] = blockStyle(node)
+			continue
+		}
+		var v any
+		if err := node.Decode(&v); err != nil {
+			log.Fatalf("reading the ohno error schema: %s", err)
+		}
+		schemas[defName(d.name)] = v
+	}
+	return schemas
+}
+
+// blockStyle clears the styles of n and its children, which are the ones of
+// json when the schema is read, so that they are written as plain yaml.
+func blockStyle(n *yaml.Node) *yaml.Node {
+	n.Style = 0
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+	return n
+}
+
+// openAPI returns an OpenAPI document in the requested format with the
+// components describing the types of the catalog.
+func openAPI(c *Catalog, format string) []byte {
+	doc := &openAPIDocument{OpenAPI: "3.1.0"}
+	doc.Components.Schemas = ohNoErrorSchemas(format == "json")
+
+	for _, group := range c.groups() {
+		codes := &openAPISchema{
+			Description: fmt.Sprintf("Codes of %s.%s", group.Package, group.Type),
+			Type:        "string",
+		}
+		names := &openAPISchema{
+			Description: fmt.Sprintf("Names of %s.%s", group.Package, group.Type),
+			Type:        "string",
+		}
+		for _, e := range group.Errors {
+			names.OneOf = append(names.OneOf, &openAPISchema{Const: e.Name, Description: e.Description})
+		}
+		for _, e := range group.canonical() {
+			codes.OneOf = append(codes.OneOf, &openAPISchema{Const: e.Code, Title: e.Name, Description: e.Description})
+		}
+
+		doc.Components.Schemas[group.Type+"Code"] = codes
+		doc.Components.Schemas[group.Type+"Name"] = names
+		doc.Components.Schemas[group.Type] = &openAPISchema{
+			Description: fmt.Sprintf("An error carrying a %s.%s", group.Package, group.Type),
+			AllOf: []*openAPISchema{
+				schemaRef("OhNoError"),
+				{
+					Type: "object",
+					Properties: map[string]*openAPISchema{
+						"package": {Const: group.Package},
+						"code":    schemaRef(group.Type + "Code"),
+						"name":    schemaRef(group.Type + "Name"),
+					},
+				},
+			},
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case "yaml":
+		fmt.Fprintf(&buf, "# Code generated by \"ohnogen %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			log.Fatalf("writing openapi: %s", err)
+		}
+		enc.Close()
+	case "json":
+		b, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			log.Fatalf("writing openapi: %s", err)
+		}
+		buf.Write(b)
+		buf.WriteString("\n")
+	default:
+		log.Fatalf("unknown openapi format %q, must be one of yaml, json", format)
+	}
+
+	return buf.Bytes()
+}
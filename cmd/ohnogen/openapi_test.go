@@ -0,0 +1,25 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import "testing"
+
+func TestOpenAPIGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		format string
+	}{
+		{golden: "openapi_yaml.golden", format: "yaml"},
+		{golden: "openapi_json.golden", format: "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			withArgs(t, "openapi", "-type", "UserError,DBError", "-format", tt.format)
+			checkGolden(t, tt.golden, openAPI(emitterCatalog, tt.format))
+		})
+	}
+}
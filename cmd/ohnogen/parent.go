@@ -0,0 +1,111 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"strings"
+)
+
+// parentAnnotation names the constant of the same type which is a more
+// generic form of the annotated one.
+const parentAnnotation = "parent"
+
+// parentLink is a constant along with its parent.
+type parentLink struct {
+	child  Value
+	parent Value
+}
+
+// parents resolves the parent annotations of the values. It returns one link
+// per annotated value, in the order of the values, and exits if a parent is
+// not a constant of the type, if constants sharing a value disagree on their
+// parent or if the parents form a cycle.
+func parents(typeName string, values []Value) []parentLink {
+	byName := make(map[string]Value, len(values))
+	for _, v := range values {
+		byName[v.originalName] = v
+	}
+	for _, v := range values {
+		// The trimmed name may be used as well as long as it is not ambiguous.
+		if _, ok := byName[v.name]; !ok {
+			byName[v.name] = v
+		}
+	}
+
+	var links []parentLink
+	parentOf := make(map[string]Value)
+	for _, v := range values {
+		name, ok := v.annotations[parentAnnotation]
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		parent, ok := byName[name]
+		if !ok {
+			log.Fatalf("parent %q of %s is not a constant of type %s", name, v.originalName, typeName)
+		}
+		if p, ok := parentOf[v.str]; ok {
+			if p.str != parent.str {
+				log.Fatalf("%s has the parent %s but another constant with the value %s has the parent %s", v.originalName, parent.originalName, v.str, p.originalName)
+			}
+			continue
+		}
+		parentOf[v.str] = parent
+		links = append(links, parentLink{child: v, parent: parent})
+	}
+
+	for _, link := range links {
+		path := []string{link.child.originalName}
+		seen := map[string]bool{link.child.str: true}
+		for p, ok := parentOf[link.child.str]; ok; p, ok = parentOf[p.str] {
+			path = append(path, p.originalName)
+			if seen[p.str] {
+				log.Fatalf("the parents of %s form a cycle: %s", link.child.originalName, strings.Join(path, " -> "))
+			}
+			seen[p.str] = true
+		}
+	}
+
+	return links
+}
+
+// buildParent generates the Parent and Is methods of a type with
+// hierarchical constants.
+func (g *Generator) buildParent(typeName string, links []parentLink) {
+	g.Printf("\n")
+	g.Printf("// Returns the parent of the error code and true, or false if it has none\n")
+	g.Printf("func (i %s) Parent() (%s, bool) {\n", typeName, typeName)
+	g.Printf("\tswitch i {\n")
+	for _, link := range links {
+		g.Printf("\tcase %s:\n", link.child.originalName)
+		g.Printf("\t\treturn %s, true\n", link.parent.originalName)
+	}
+	g.Printf("\t}\n")
+	g.Printf("\treturn 0, false\n")
+	g.Printf("}\n")
+	g.Printf(isFunc, typeName)
+}
+
+// Arguments to format are:
+//
+//	[1]: type name
+const isFunc = `
+// Reports whether the target is this error code or one of its parents. This
+// lets [errors.Is] match an error against a more generic error code
+func (i %[1]s) Is(target error) bool {
+	t, ok := target.(%[1]s)
+	if !ok {
+		return false
+	}
+	for c, ok := i, true; ok; c, ok = c.Parent() {
+		if c == t {
+			return true
+		}
+	}
+	return false
+}
+`
@@ -0,0 +1,77 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// parentValue returns a constant named name with the value value, annotated
+// with the given parent unless it is empty.
+func parentValue(name string, value int, parent string) Value {
+	v := Value{originalName: name, name: name, value: uint64(value), signed: true, str: strconv.Itoa(value)}
+	if parent != "" {
+		v.annotations = map[string]string{parentAnnotation: parent}
+	}
+	return v
+}
+
+func TestParents(t *testing.T) {
+	values := []Value{
+		parentValue("NotFound", 1, ""),
+		parentValue("UserNotFound", 2, "NotFound"),
+		parentValue("DeletedUserNotFound", 3, "UserNotFound"),
+		parentValue("MissingUser", 2, "NotFound"),
+	}
+
+	var got []string
+	for _, link := range parents("E", values) {
+		got = append(got, link.child.originalName+"->"+link.parent.originalName)
+	}
+	want := []string{"UserNotFound->NotFound", "DeletedUserNotFound->UserNotFound"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parents %v, want %v", got, want)
+	}
+}
+
+func TestParentsFatal(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []Value
+		want   string
+	}{
+		{
+			name:   "unknown parent",
+			values: []Value{parentValue("NotFound", 1, ""), parentValue("UserNotFound", 2, "Missing")},
+			want:   `parent "Missing" of UserNotFound is not a constant of type E`,
+		},
+		{
+			name:   "disagreeing parents",
+			values: []Value{parentValue("A", 1, ""), parentValue("B", 2, ""), parentValue("C", 3, "A"), parentValue("D", 3, "B")},
+			want:   "D has the parent B but another constant with the value 3 has the parent A",
+		},
+		{
+			name:   "cycle",
+			values: []Value{parentValue("A", 1, "C"), parentValue("B", 2, "A"), parentValue("C", 3, "B")},
+			want:   "the parents of A form a cycle: A -> C -> B -> A",
+		},
+		{
+			name:   "own parent",
+			values: []Value{parentValue("A", 1, "A")},
+			want:   "the parents of A form a cycle: A -> A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectFatal(t, tt.want, func() {
+				parents("E", tt.values)
+			})
+		})
+	}
+}
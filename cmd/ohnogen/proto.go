@@ -0,0 +1,157 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Policies for the zero value of the generated proto enums.
+const (
+	// Add a T_UNSPECIFIED = 0 value, no constant may be 0
+	protoZeroReserve = "reserve"
+	// Use the constant with the value 0 as the zero value
+	protoZeroAllow = "allow"
+)
+
+// protoValue is a single value of a generated proto enum.
+type protoValue struct {
+	name       string
+	number     int64
+	comment    string
+	deprecated bool
+}
+
+// proto returns a proto3 file in the package protoPackage declaring an enum
+// for each of the types of the catalog, with the zero value handled as
+// zeroPolicy says.
+func proto(c *Catalog, protoPackage, zeroPolicy string) []byte {
+	if zeroPolicy != protoZeroReserve && zeroPolicy != protoZeroAllow {
+		log.Fatalf("protozero can only be one of %s, %s current value = %s", protoZeroReserve, protoZeroAllow, zeroPolicy)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"ohnogen %s\"; DO NOT EDIT.\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(&buf, "\n")
+	fmt.Fprintf(&buf, "syntax = \"proto3\";\n")
+	fmt.Fprintf(&buf, "\n")
+	fmt.Fprintf(&buf, "package %s;\n", protoPackage)
+
+	for _, group := range c.groups() {
+		writeProtoEnum(&buf, group, zeroPolicy)
+	}
+
+	return buf.Bytes()
+}
+
+// writeProtoEnum writes the enum declaration of a single type.
+func writeProtoEnum(buf *bytes.Buffer, group catalogGroup, zeroPolicy string) {
+	prefix := upperSnakeCase(group.Type) + "_"
+	unspecified := protoValue{
+		name:    prefix + "UNSPECIFIED",
+		comment: "No error, the zero value of the enum",
+	}
+
+	var values []protoValue
+	hasZero := false
+	for _, e := range group.Errors {
+		number, err := strconv.ParseInt(e.Value, 10, 64)
+		if err != nil || number < math.MinInt32 || number > math.MaxInt32 {
+			log.Fatalf("value %s of %s does not fit in a proto enum", e.Value, e.key())
+		}
+		if number == 0 {
+			if zeroPolicy == protoZeroReserve {
+				log.Fatalf("%s has the value 0 which is reserved for %s, renumber it or use -protozero=%s", e.key(), unspecified.name, protoZeroAllow)
+			}
+			hasZero = true
+		}
+		values = append(values, protoValue{
+			name:       prefix + upperSnakeCase(e.Name),
+			number:     number,
+			comment:    e.Description,
+			deprecated: e.deprecated(),
+		})
+	}
+
+	if !hasZero {
+		values = append([]protoValue{unspecified}, values...)
+	} else {
+		// proto3 needs the zero value first.
+		for i, v := range values {
+			if v.number == 0 {
+				copy(values[1:i+1], values[:i])
+				values[0] = v
+				break
+			}
+		}
+	}
+
+	names := make(map[string]bool, len(values))
+	numbers := make(map[int64]bool, len(values))
+	alias := false
+	for _, v := range values {
+		if names[v.name] {
+			log.Fatalf("more than one value of %s is named %s in proto", group.Type, v.name)
+		}
+		names[v.name] = true
+		if numbers[v.number] {
+			alias = true
+		}
+		numbers[v.number] = true
+	}
+
+	fmt.Fprintf(buf, "\n")
+	fmt.Fprintf(buf, "// %s holds the error codes of %s.%s\n", group.Type, group.Package, group.Type)
+	fmt.Fprintf(buf, "enum %s {\n", group.Type)
+	if alias {
+		fmt.Fprintf(buf, "  option allow_alias = true;\n")
+	}
+	for _, v := range values {
+		if v.comment != "" {
+			fmt.Fprintf(buf, "  // %s\n", strings.Join(strings.Fields(v.comment), " "))
+		}
+		fmt.Fprintf(buf, "  %s = %d", v.name, v.number)
+		if v.deprecated {
+			fmt.Fprintf(buf, " [deprecated = true]")
+		}
+		fmt.Fprintf(buf, ";\n")
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+// upperSnakeCase converts a Go identifier like NotFound or HTTPTimeout to
+// NOT_FOUND or HTTP_TIMEOUT.
+func upperSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if r == '_' {
+			if b.Len() > 0 && !strings.HasSuffix(b.String(), "_") {
+				b.WriteRune('_')
+			}
+			continue
+		}
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				if !strings.HasSuffix(b.String(), "_") {
+					b.WriteRune('_')
+				}
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+
+	return strings.Trim(b.String(), "_")
+}
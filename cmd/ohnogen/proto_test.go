@@ -0,0 +1,62 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import "testing"
+
+func TestUpperSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "NotFound", want: "NOT_FOUND"},
+		{name: "HTTPTimeout", want: "HTTP_TIMEOUT"},
+		{name: "ErrHTTP", want: "ERR_HTTP"},
+		{name: "ID", want: "ID"},
+		{name: "userID", want: "USER_ID"},
+		{name: "Error2Code", want: "ERROR2_CODE"},
+		{name: "Not_Found", want: "NOT_FOUND"},
+		{name: "_Internal__Error_", want: "INTERNAL_ERROR"},
+		{name: "lower", want: "LOWER"},
+	}
+
+	for _, tt := range tests {
+		if got := upperSnakeCase(tt.name); got != tt.want {
+			t.Errorf("upperSnakeCase(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// zeroCatalog holds a constant with the value 0, which only -protozero=allow
+// accepts.
+var zeroCatalog = &Catalog{Errors: []CatalogEntry{
+	{Package: "status", Type: "Status", Name: "Failed", Identifier: "Failed", Code: "1", Value: "1", Description: "The call failed"},
+	{Package: "status", Type: "Status", Name: "OK", Identifier: "OK", Code: "0", Value: "0", Description: "The call succeeded"},
+}}
+
+func TestProtoGolden(t *testing.T) {
+	tests := []struct {
+		golden     string
+		catalog    *Catalog
+		zeroPolicy string
+	}{
+		{golden: "proto_reserve.golden", catalog: emitterCatalog, zeroPolicy: protoZeroReserve},
+		{golden: "proto_allow.golden", catalog: zeroCatalog, zeroPolicy: protoZeroAllow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			withArgs(t, "proto", "-protozero", tt.zeroPolicy)
+			checkGolden(t, tt.golden, proto(tt.catalog, "errors.v1", tt.zeroPolicy))
+		})
+	}
+}
+
+func TestProtoReservedZero(t *testing.T) {
+	expectFatal(t, "Status.OK has the value 0 which is reserved for STATUS_UNSPECIFIED", func() {
+		proto(zeroCatalog, "errors.v1", protoZeroReserve)
+	})
+}
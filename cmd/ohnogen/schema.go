@@ -0,0 +1,107 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"gopkg.in/yaml.v3"
+)
+
+// defsRefPrefix is the prefix of the references between the definitions of
+// the schema of the ohno package.
+const defsRefPrefix = "#/$defs/"
+
+// schemaDef is a definition of the json schema of the ohno package, which
+// the TypeScript and OpenAPI outputs are derived from so that they describe
+// the same json.
+type schemaDef struct {
+	name   string // Name in $defs, like join_error
+	schema *yaml.Node
+}
+
+// ohnoSchemaDefs returns the definitions of the json schema shipped with the
+// ohno package in the order they are declared. The schema is read as yaml,
+// of which json is a subset, to keep the order of the properties.
+func ohnoSchemaDefs() []schemaDef {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(ohno.JSONSchema(), &doc); err != nil {
+		log.Fatalf("reading the ohno error schema: %s", err)
+	}
+	defs := mappingValue(doc.Content[0], "$defs")
+	if defs == nil {
+		log.Fatal("the ohno error schema has no definitions")
+	}
+
+	var result []schemaDef
+	for i := 0; i+1 < len(defs.Content); i += 2 {
+		result = append(result, schemaDef{name: defs.Content[i].Value, schema: defs.Content[i+1]})
+	}
+	return result
+}
+
+// mappingValue returns the value of key in the mapping n, or nil if n is not
+// a mapping or has no such key.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// scalarValue returns the text of the scalar value of key in the mapping n,
+// or an empty string if there is none.
+func scalarValue(n *yaml.Node, key string) string {
+	if v := mappingValue(n, key); v != nil && v.Kind == yaml.ScalarNode {
+		return v.Value
+	}
+	return ""
+}
+
+// defName turns the name of a definition like join_error into the name of
+// the OpenAPI component, OhNoJoinError.
+func defName(def string) string {
+	var b strings.Builder
+	b.WriteString("OhNo")
+	for _, word := range strings.Split(def, "_") {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// refDef returns the name of the definition a reference like
+// #/$defs/cause points to, or an empty string if it is not such a reference.
+func refDef(ref string) string {
+	if !strings.HasPrefix(ref, defsRefPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(ref, defsRefPrefix)
+}
+
+// rewriteRefs returns a copy of n with the references to the definitions
+// replaced by rename applied to the names of the definitions.
+func rewriteRefs(n *yaml.Node, rename func(def string) string) *yaml.Node {
+	c := *n
+	c.Content = make([]*yaml.Node, len(n.Content))
+	for i, child := range n.Content {
+		c.Content[i] = rewriteRefs(child, rename)
+		if n.Kind == yaml.MappingNode && i%2 == 1 && n.Content[i-1].Value == "$ref" {
+			if def := refDef(child.Value); def != "" {
+				c.Content[i].Value = rename(def)
+			}
+		}
+	}
+	return &c
+}
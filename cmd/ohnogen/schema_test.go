@@ -0,0 +1,52 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTSSchemaTypes(t *testing.T) {
+	ts := tsSchemaTypes()
+	for _, want := range []string{
+		"export interface OhNoErrorJSON {",
+		`  schema_version: "1";`,
+		"  code?: string;",
+		`  severity?: "debug" | "info" | "warning" | "error" | "critical";`,
+		"  caused_by?: OhNoCauseJSON;",
+		"  source_information?: OhNoSourceInformation;",
+		"export interface OhNoJoinErrorJSON {",
+		"  errors: OhNoCauseJSON[];",
+		"export type OhNoCauseJSON = OhNoErrorJSON | OhNoJoinErrorJSON | string;",
+		"export interface OhNoSourceInformation {",
+		"  line: number;",
+	} {
+		if !strings.Contains(ts, want) {
+			t.Errorf("missing %q in\n%s", want, ts)
+		}
+	}
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	b, err := json.Marshal(ohNoErrorSchemas(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(b)
+	if strings.Contains(doc, defsRefPrefix) {
+		t.Errorf("references to the definitions of the schema left in %s", doc)
+	}
+	for _, name := range []string{"OhNoError", "OhNoJoinError", "OhNoCause", "OhNoSourceInformation"} {
+		if !strings.Contains(doc, `"`+schemaRefPrefix+name+`"`) {
+			t.Errorf("no reference to %s in %s", name, doc)
+		}
+		if _, ok := ohNoErrorSchemas(false)[name]; !ok {
+			t.Errorf("no schema %s", name)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+)
+
+// severityAnnotation gives the ohno.Severity of a constant by its name.
+const severityAnnotation = "severity"
+
+// severityGroup is a severity along with the constants having it.
+type severityGroup struct {
+	severity ohno.Severity
+	values   []Value
+}
+
+// severities groups the values by the severity they are annotated with, in
+// increasing order of severity. Constants sharing a value appear only once.
+// It exits if a severity is unknown or if constants sharing a value disagree
+// on their severity.
+func severities(typeName string, values []Value) []severityGroup {
+	byValue := make(map[string]ohno.Severity)
+	groups := make(map[ohno.Severity]*severityGroup)
+	for _, v := range values {
+		name, ok := v.annotations[severityAnnotation]
+		if !ok {
+			continue
+		}
+		severity, err := ohno.ParseSeverity(strings.TrimSpace(name))
+		if err != nil {
+			log.Fatalf("%s of type %s: %s", v.originalName, typeName, err)
+		}
+		if s, ok := byValue[v.str]; ok {
+			if s != severity {
+				log.Fatalf("%s has the severity %s but another constant with the value %s has the severity %s", v.originalName, severity, v.str, s)
+			}
+			continue
+		}
+		byValue[v.str] = severity
+		if severity == ohno.SeverityUnspecified {
+			continue
+		}
+		if groups[severity] == nil {
+			groups[severity] = &severityGroup{severity: severity}
+		}
+		groups[severity].values = append(groups[severity].values, v)
+	}
+
+	result := make([]severityGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].severity < result[j].severity })
+
+	return result
+}
+
+// severityConstant returns the expression naming s in the generated code,
+// like ohno.SeverityWarning.
+func severityConstant(s ohno.Severity) string {
+	name := s.String()
+	return "ohno.Severity" + strings.ToUpper(name[:1]) + name[1:]
+}
+
+// buildSeverity generates the Severity method of a type with constants
+// annotated with a severity.
+func (g *Generator) buildSeverity(typeName string, groups []severityGroup) {
+	g.addImport(ohnoImport)
+	g.Printf("\n")
+	g.Printf("// Returns the severity of the error code\n")
+	g.Printf("func (i %s) Severity() ohno.Severity {\n", typeName)
+	g.Printf("\tswitch i {\n")
+	for _, group := range groups {
+		names := make([]string, len(group.values))
+		for i, v := range group.values {
+			names[i] = v.originalName
+		}
+		g.Printf("\tcase %s:\n", strings.Join(names, ", "))
+		g.Printf("\t\treturn %s\n", severityConstant(group.severity))
+	}
+	g.Printf("\t}\n")
+	g.Printf("\treturn ohno.SeverityUnspecified\n")
+	g.Printf("}\n")
+}
@@ -0,0 +1,124 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"regexp"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// paramAnnotationPrefix starts the annotations giving the Go type of a
+// placeholder of the description, like param.Count=int. The type is string
+// by default.
+const paramAnnotationPrefix = "param."
+
+// placeholderPattern matches the placeholders of a description like
+// {{.UserID}}.
+var placeholderPattern = regexp.MustCompile(`{{-?\s*\.([A-Za-z_][A-Za-z0-9_]*)\s*-?}}`)
+
+// templateParam is a parameter of a generated constructor.
+type templateParam struct {
+	field string // Name of the placeholder
+	name  string // Name of the parameter
+	typ   string // Go type of the parameter
+}
+
+// templateParams returns the parameters of the constructor of v, one per
+// distinct placeholder of its description in order of appearance, or nil if
+// the description has none. It exits if the description is not a valid
+// template or a parameter type is not a valid Go type.
+func templateParams(v Value) []templateParam {
+	if !strings.Contains(v.description, "{{") {
+		return nil
+	}
+	if _, err := template.New(v.originalName).Parse(v.description); err != nil {
+		log.Fatalf("description of %s is not a valid template: %s", v.originalName, err)
+	}
+
+	var params []templateParam
+	seen := make(map[string]bool)
+	for _, match := range placeholderPattern.FindAllStringSubmatch(v.description, -1) {
+		field := match[1]
+		if seen[field] {
+			continue
+		}
+		seen[field] = true
+
+		typ := "string"
+		if t, ok := v.annotations[paramAnnotationPrefix+field]; ok {
+			typ = strings.TrimSpace(t)
+			if _, err := parser.ParseExpr(typ); err != nil {
+				log.Fatalf("type %q of the parameter %s of %s is not a valid Go type: %s", typ, field, v.originalName, err)
+			}
+		}
+		params = append(params, templateParam{field: field, name: paramName(field), typ: typ})
+	}
+
+	return params
+}
+
+// paramName turns the name of a placeholder like UserID or URLPath into the
+// name of a parameter like userID or urlPath. Names which would shadow a
+// keyword, a predeclared identifier or a package used by the constructor get
+// the suffix Arg.
+func paramName(field string) string {
+	runes := []rune(field)
+	i := 0
+	for i < len(runes) && unicode.IsUpper(runes[i]) {
+		i++
+	}
+	// Keep the last capital of an initialism starting a word, as in URLPath.
+	if i > 1 && i < len(runes) && unicode.IsLower(runes[i]) {
+		i--
+	}
+	if i == 0 {
+		i = 1
+	}
+	name := strings.ToLower(string(runes[:i])) + string(runes[i:])
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil || name == "ohno" || name == "sourceinfo" || name == "time" {
+		name += "Arg"
+	}
+	return name
+}
+
+// buildConstructors generates a constructor for every constant whose
+// description has placeholders. They return an ohno.OhNoError, so they are
+// only generated with -ohno.
+func (g *Generator) buildConstructors(typeName string, groups []valueNames) {
+	for _, n := range groups {
+		for _, v := range append([]Value{n.canonical}, n.others...) {
+			params := templateParams(v)
+			if len(params) == 0 {
+				continue
+			}
+			if !g.ohnoEnable {
+				log.Printf("warning: the description of %s has placeholders, set -ohno to generate its constructor", v.originalName)
+				continue
+			}
+
+			decls := make([]string, len(params))
+			args := make([]string, len(params))
+			for i, p := range params {
+				decls[i] = p.name + " " + p.typ
+				args[i] = fmt.Sprintf("%q: %s", p.field, p.name)
+			}
+
+			g.Printf("\n")
+			g.Printf("// Returns a new [ohno.OhNoError] of %s whose message is its description\n", v.originalName)
+			g.Printf("// filled in with the arguments\n")
+			g.Printf("func %sErr(%s) error {\n", v.originalName, strings.Join(decls, ", "))
+			g.Printf("\treturn ohno.NewFromTemplate(%s, %q, map[string]any{%s}, nil, sourceinfo.NoSourceInfo, sourceinfo.DefaultCallDepth+1, time.Time{}, \"\")\n", v.originalName, v.description, strings.Join(args, ", "))
+			g.Printf("}\n")
+		}
+	}
+}
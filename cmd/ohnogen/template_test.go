@@ -0,0 +1,144 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestParamName(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "UserID", want: "userID"},
+		{field: "URLPath", want: "urlPath"},
+		{field: "ID", want: "id"},
+		{field: "count", want: "count"},
+		{field: "Type", want: "typeArg"},
+		{field: "Any", want: "anyArg"},
+		{field: "String", want: "stringArg"},
+		{field: "Error", want: "errorArg"},
+		{field: "Len", want: "lenArg"},
+		{field: "Nil", want: "nilArg"},
+		{field: "Time", want: "timeArg"},
+		{field: "Ohno", want: "ohnoArg"},
+	}
+
+	for _, tt := range tests {
+		if got := paramName(tt.field); got != tt.want {
+			t.Errorf("paramName(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+}
+
+// stubs declare the parts of the packages used by the generated constructors
+// so that they can be type checked without loading the real ones.
+var stubs = map[string]string{
+	"time":                                 "package time\ntype Time struct{}",
+	"github.com/A-0-5/ohno/pkg/sourceinfo": "package sourceinfo\ntype SourceInfoType int\nconst (\n\tNoSourceInfo SourceInfoType = 0\n\tDefaultCallDepth = 2\n)",
+	"github.com/A-0-5/ohno/pkg/ohno":       "package ohno\nimport (\n\t\"time\"\n\t\"github.com/A-0-5/ohno/pkg/sourceinfo\"\n)\nfunc NewFromTemplate(ohnoer error, messageTemplate string, args map[string]any, cause error, sourceInfoType sourceinfo.SourceInfoType, callDepth int, timeStamp time.Time, timestampLayout string) error { return nil }",
+}
+
+// stubImporter type checks the stubs on demand.
+type stubImporter struct {
+	fset *token.FileSet
+	pkgs map[string]*types.Package
+}
+
+func (s *stubImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := s.pkgs[path]; ok {
+		return pkg, nil
+	}
+	src, ok := stubs[path]
+	if !ok {
+		return nil, fmt.Errorf("no stub for %s", path)
+	}
+	pkg, err := s.check(path, src)
+	if err != nil {
+		return nil, err
+	}
+	s.pkgs[path] = pkg
+	return pkg, nil
+}
+
+func (s *stubImporter) check(path, src string) (*types.Package, error) {
+	f, err := parser.ParseFile(s.fset, path+".go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	conf := types.Config{Importer: s}
+	return conf.Check(path, s.fset, []*ast.File{f}, nil)
+}
+
+func TestBuildConstructors(t *testing.T) {
+	values := []Value{
+		{
+			originalName: "NotFound",
+			description:  "User {{.UserID}} was not found",
+		},
+		{
+			originalName: "Invalid",
+			description:  "{{.Type}} of {{.Any}} is not {{.String}}, {{.Count}} times",
+			annotations:  map[string]string{"param.Count": "int"},
+		},
+		{
+			originalName: "Internal",
+			description:  "An internal error occurred",
+		},
+	}
+	var groups []valueNames
+	for _, v := range values {
+		groups = append(groups, valueNames{canonical: v})
+	}
+
+	g := Generator{ohnoEnable: true}
+	g.buildConstructors("E", groups)
+	generated := g.buf.String()
+
+	for _, want := range []string{
+		"func NotFoundErr(userID string) error {",
+		"func InvalidErr(typeArg string, anyArg string, stringArg string, count int) error {",
+		`map[string]any{"Type": typeArg, "Any": anyArg, "String": stringArg, "Count": count}`,
+	} {
+		if !strings.Contains(generated, want) {
+			t.Errorf("generated code does not contain %q:\n%s", want, generated)
+		}
+	}
+	if strings.Contains(generated, "InternalErr") {
+		t.Errorf("constructor generated for a description without placeholders:\n%s", generated)
+	}
+
+	src := `package p
+
+import (
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+type E int
+
+const (
+	NotFound E = iota
+	Invalid
+	Internal
+)
+
+func (E) Error() string { return "" }
+` + generated
+	s := &stubImporter{fset: token.NewFileSet(), pkgs: make(map[string]*types.Package)}
+	if _, err := s.check("p", src); err != nil {
+		t.Errorf("generated code does not compile: %s\n%s", err, generated)
+	}
+}
@@ -0,0 +1,7 @@
+package tests_test
+
+type ExternalError int
+
+const (
+	Flaky ExternalError = iota + 1 // The external test is flaky
+)
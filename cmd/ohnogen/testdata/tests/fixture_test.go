@@ -0,0 +1,8 @@
+package tests
+
+type FixtureError int
+
+const (
+	Unavailable FixtureError = iota // The fake backend is unavailable
+	Corrupted                       // The fixture is corrupted
+)
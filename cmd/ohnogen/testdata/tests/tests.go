@@ -0,0 +1,2 @@
+// Package tests declares an enum only in its test files, see the -tests flag.
+package tests
@@ -0,0 +1,46 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestTestsFlag(t *testing.T) {
+	tests := []struct {
+		typeName string
+		pkg      string // package the methods are generated in
+	}{
+		{typeName: "FixtureError", pkg: "tests"},
+		{typeName: "ExternalError", pkg: "tests_test"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			g := Generator{lineComment: true, codeBase: 10, tests: true}
+			g.parsePackage([]string{"./testdata/tests"}, nil, tt.typeName)
+			if g.pkg.name != tt.pkg {
+				t.Fatalf("package %s, want %s", g.pkg.name, tt.pkg)
+			}
+
+			g.generate(tt.typeName, g.values(tt.typeName))
+			src := g.format()
+			f, err := parser.ParseFile(token.NewFileSet(), "", src, 0)
+			if err != nil {
+				t.Fatalf("generated code does not parse: %s\n%s", err, src)
+			}
+			if f.Name.Name != tt.pkg {
+				t.Errorf("generated package %s, want %s", f.Name.Name, tt.pkg)
+			}
+			if want := "func (i " + tt.typeName + ") String() string"; !strings.Contains(string(src), want) {
+				t.Errorf("generated code does not contain %q:\n%s", want, src)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxSafeInteger is the largest integer a JavaScript number holds exactly.
+var maxSafeInteger = big.NewInt(1<<53 - 1)
+
+// tsType holds the data of a single type for the TypeScript template.
+type tsType struct {
+	Type    string
+	Package string
+	// Every constant including the ones sharing a value with another.
+	Entries []CatalogEntry
+	// A single constant per value, the one String() returns.
+	Unique []CatalogEntry
+}
+
+// typeScript returns a TypeScript module describing the types of the catalog,
+// declaring the codes as a const enum when enum is set.
+func typeScript(c *Catalog, enum bool) []byte {
+	data := struct {
+		Header string
+		Enum   bool
+		Schema string
+		Types  []tsType
+	}{
+		Header: strings.Join(os.Args[1:], " "),
+		Enum:   enum,
+		Schema: tsSchemaTypes(),
+	}
+
+	for _, group := range c.groups() {
+		t := tsType{
+			Type:    group.Type,
+			Package: group.Package,
+			Entries: group.Errors,
+			Unique:  group.canonical(),
+		}
+		for _, e := range group.Errors {
+			v, ok := new(big.Int).SetString(e.Value, 10)
+			if !ok || new(big.Int).Abs(v).Cmp(maxSafeInteger) > 0 {
+				log.Fatalf("value %s of %s cannot be represented exactly in TypeScript", e.Value, e.key())
+			}
+		}
+		data.Types = append(data.Types, t)
+	}
+
+	var buf bytes.Buffer
+	if err := tsTemplate.Execute(&buf, data); err != nil {
+		log.Fatalf("generating typescript: %s", err)
+	}
+	return buf.Bytes()
+}
+
+// tsNames are the names of the TypeScript types of the definitions of the
+// schema of the ohno package which do not follow the OhNo<Name>JSON pattern.
+var tsNames = map[string]string{
+	"source_information": "OhNoSourceInformation",
+}
+
+// tsDefName returns the name of the TypeScript type of a definition of the
+// schema of the ohno package.
+func tsDefName(def string) string {
+	if name, ok := tsNames[def]; ok {
+		return name
+	}
+	return defName(def) + "JSON"
+}
+
+// tsSchemaTypes returns the TypeScript types of the definitions of the schema
+// of the ohno package, objects with properties as interfaces and anything
+// else as type aliases.
+func tsSchemaTypes() string {
+	var b strings.Builder
+	for _, d := range ohnoSchemaDefs() {
+		fmt.Fprintf(&b, "/** %s */\n", tsComment(scalarValue(d.schema, "description")))
+
+		properties := mappingValue(d.schema, "properties")
+		if properties == nil {
+			fmt.Fprintf(&b, "export type %s = %s;\n\n", tsDefName(d.name), tsSchemaType(d.schema))
+			continue
+		}
+
+		required := make(map[string]bool)
+		if r := mappingValue(d.schema, "required"); r != nil {
+			for _, n := range r.Content {
+				required[n.Value] = true
+			}
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", tsDefName(d.name))
+		for i := 0; i+1 < len(properties.Content); i += 2 {
+			name, p := properties.Content[i].Value, properties.Content[i+1]
+			if description := scalarValue(p, "description"); description != "" {
+				fmt.Fprintf(&b, "  /** %s */\n", tsComment(description))
+			}
+			optional := "?"
+			if required[name] {
+				optional = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", name, optional, tsSchemaType(p))
+		}
+		b.WriteString("}\n\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// tsSchemaType returns the TypeScript type of the json schema n.
+func tsSchemaType(n *yaml.Node) string {
+	if def := refDef(scalarValue(n, "$ref")); def != "" {
+		return tsDefName(def)
+	}
+	if c := mappingValue(n, "const"); c != nil {
+		return tsString(c.Value)
+	}
+	if enum := mappingValue(n, "enum"); enum != nil {
+		values := make([]string, len(enum.Content))
+		for i, v := range enum.Content {
+			values[i] = tsString(v.Value)
+		}
+		return strings.Join(values, " | ")
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if schemas := mappingValue(n, key); schemas != nil {
+			types := make([]string, len(schemas.Content))
+			for i, s := range schemas.Content {
+				types[i] = tsSchemaType(s)
+			}
+			return strings.Join(types, " | ")
+		}
+	}
+
+	switch scalarValue(n, "type") {
+	case "string":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		items := tsSchemaType(mappingValue(n, "items"))
+		if strings.Contains(items, " ") {
+			items = "(" + items + ")"
+		}
+		return items + "[]"
+	case "object":
+		return "Record<string, unknown>"
+	}
+	return "unknown"
+}
+
+// tsString returns s as a string literal. A json string is a valid literal.
+func tsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// tsComment turns s into the text of a single line /** */ comment.
+func tsComment(s string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(s), " "), "*/", "*\\/")
+}
+
+var tsTemplate = template.Must(template.New("ts").Funcs(template.FuncMap{
+	"quote":   tsString,
+	"comment": tsComment,
+}).Parse(`// Code generated by "ohnogen {{.Header}}"; DO NOT EDIT.
+
+{{.Schema}}{{range .Types}}{{$t := .Type}}{{$pkg := .Package}}
+{{if $.Enum}}/** The values of {{$pkg}}.{{$t}}. */
+export const enum {{$t}} {
+{{range .Entries}}  /** {{comment .Description}}{{if .Deprecated}} @deprecated{{end}} */
+  {{.Name}} = {{.Value}},
+{{end}}}
+{{else}}/** The values of {{$pkg}}.{{$t}}. */
+export const {{$t}} = {
+{{range .Entries}}  /** {{comment .Description}}{{if .Deprecated}} @deprecated{{end}} */
+  {{.Name}}: {{.Value}},
+{{end}}} as const;
+
+export type {{$t}} = (typeof {{$t}})[keyof typeof {{$t}}];
+{{end}}
+/** The name of each {{$t}} as returned by its String() method. */
+export const {{$t}}Name: { readonly [K in {{$t}}]: string } = {
+{{range .Unique}}  {{quote .Value}}: {{quote .Name}},
+{{end}}};
+
+/** The code of each {{$t}} as returned by its Code() method. */
+export const {{$t}}Code: { readonly [K in {{$t}}]: string } = {
+{{range .Unique}}  {{quote .Value}}: {{quote .Code}},
+{{end}}};
+
+/** The description of each {{$t}} as returned by its Description() method. */
+export const {{$t}}Description: { readonly [K in {{$t}}]: string } = {
+{{range .Unique}}  {{quote .Value}}: {{quote .Description}},
+{{end}}};
+
+/** Returns the description of a {{$t}}. */
+export function describe{{$t}}(value: {{$t}}): string {
+  return {{$t}}Description[value];
+}
+
+/** An error carrying a {{$t}} marshaled to json by the ohno package, discriminated on its name. */
+export type {{$t}}JSON =
+{{range $i, $e := .Unique}}{{if $i}}
+{{end}}  | (OhNoErrorJSON & { package: {{quote $pkg}}; code: {{quote .Code}}; name: {{quote .Name}} }){{end}};
+{{end}}`))
@@ -0,0 +1,25 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package main
+
+import "testing"
+
+func TestTypeScriptGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		enum   bool
+	}{
+		{golden: "ts.golden", enum: false},
+		{golden: "ts_enum.golden", enum: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			withArgs(t, "ts", "-type", "UserError,DBError")
+			checkGolden(t, tt.golden, typeScript(emitterCatalog, tt.enum))
+		})
+	}
+}
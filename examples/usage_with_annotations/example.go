@@ -0,0 +1,43 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+// This example demonstrates the //ohno: annotations understood by [ohnogen].
+// They attach more information to the error codes of a custom type
+// [StorageError], and ohnogen generates the methods giving access to it.
+//
+// [ohnogen]: https://pkg.go.dev/github.com/A-0-5/ohno/cmd/ohnogen
+package usage_with_annotations
+
+//go:generate go run ../../cmd/ohnogen -type=StorageError -formatbase=16 -output=example_errors.go -ohno -locales=locales "-docurl=https://docs.example.com/errors/{{.Type}}/{{.Name}}"
+
+// We first define a custom type like the one below
+type StorageError int
+
+// The codes below form a hierarchy with //ohno:parent, so that a
+// DeletedFileNotFound error is also a FileNotFound and a NotFound error when
+// checked with [errors.Is]. Their severities are given with //ohno:severity.
+// The documentation URL of each code follows the -docurl template, apart from
+// Internal which has its own. What can be done about an error is given as a
+// hint, either after the description or with //ohno:hint. The descriptions in
+// German are kept in locales/de.yaml, the one in Swiss German is given with
+// //ohno:desc.de-CH. The description of QuotaExceeded is a template, so a
+// QuotaExceededErr constructor taking its arguments is generated, the type of
+// Bytes being given with //ohno:param.Bytes.
+const (
+	//ohno:severity=info
+	NotFound StorageError = 1 + iota // Requested resource was not found
+	//ohno:parent=NotFound
+	//ohno:severity=warning
+	//ohno:desc.de-CH=D Datei isch nöd gfunde worde
+	FileNotFound // File was not found | hint: check the path of the file
+	//ohno:parent=FileNotFound
+	DeletedFileNotFound // File was deleted
+	//ohno:docurl=https://status.example.com
+	//ohno:hint=try again once the status page is green
+	//ohno:severity=critical
+	Internal // An internal error occurred
+	//ohno:param.Bytes=int64
+	QuotaExceeded // Quota of bucket {{.Bucket}} exceeded by {{.Bytes}} bytes
+)
@@ -0,0 +1,152 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package usage_with_annotations_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/A-0-5/ohno/examples/usage_with_annotations"
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// The Is method generated for a type with parents lets [errors.Is] match an
+// error code, or an OhNoError carrying it, against any of its parents.
+func ExampleStorageError_Is() {
+	err := usage_with_annotations.DeletedFileNotFound.OhNo("loading report.pdf", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+
+	fmt.Println(errors.Is(err, usage_with_annotations.DeletedFileNotFound))
+	fmt.Println(errors.Is(err, usage_with_annotations.FileNotFound))
+	fmt.Println(errors.Is(err, usage_with_annotations.NotFound))
+	fmt.Println(errors.Is(err, usage_with_annotations.Internal))
+	fmt.Println(errors.Is(usage_with_annotations.FileNotFound, usage_with_annotations.DeletedFileNotFound))
+	fmt.Println(errors.Is(ohno.Join(usage_with_annotations.Internal, err), usage_with_annotations.NotFound))
+	// Output:
+	// true
+	// true
+	// true
+	// false
+	// false
+	// true
+}
+
+// Parent returns the code a code has been annotated with, so the whole chain
+// of parents can be walked.
+func ExampleStorageError_Parent() {
+	for c, ok := usage_with_annotations.DeletedFileNotFound, true; ok; c, ok = c.Parent() {
+		fmt.Println(c.String())
+	}
+	// Output:
+	// DeletedFileNotFound
+	// FileNotFound
+	// NotFound
+}
+
+// Severity returns the severity a code has been annotated with, and
+// [ohno.MaxSeverity] finds the highest one among an error, its causes and the
+// errors joined with it.
+func ExampleStorageError_Severity() {
+	fmt.Println(usage_with_annotations.FileNotFound.Severity())
+	fmt.Println(usage_with_annotations.DeletedFileNotFound.Severity())
+
+	notFound := usage_with_annotations.NotFound.OhNo("loading the user", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+	fmt.Println(ohno.MaxSeverity(notFound))
+
+	internal := usage_with_annotations.Internal.OhNo("writing the cache", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+	fmt.Println(ohno.MaxSeverity(ohno.Join(notFound, usage_with_annotations.FileNotFound, internal)))
+	fmt.Println(ohno.MaxSeverity(ohno.Join(notFound, usage_with_annotations.FileNotFound)))
+	fmt.Println(ohno.MaxSeverity(errors.New("plain")))
+	// Output:
+	// warning
+	// unspecified
+	// info
+	// critical
+	// warning
+	// unspecified
+}
+
+// DocURL returns the documentation URL of a code, which is also marshaled in
+// the help field of an OhNoError.
+func ExampleStorageError_DocURL() {
+	fmt.Println(usage_with_annotations.DeletedFileNotFound.DocURL())
+	fmt.Println(usage_with_annotations.Internal.DocURL())
+
+	err := usage_with_annotations.FileNotFound.OhNo("loading report.pdf", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+	var marshaled struct {
+		Help string `json:"help"`
+	}
+	b, _ := json.Marshal(err)
+	_ = json.Unmarshal(b, &marshaled)
+	fmt.Println(marshaled.Help)
+	// Output:
+	// https://docs.example.com/errors/StorageError/DeletedFileNotFound
+	// https://status.example.com
+	// https://docs.example.com/errors/StorageError/FileNotFound
+}
+
+// Hint returns what can be done about an error, an OhNoError prints it after
+// its message.
+func ExampleStorageError_Hint() {
+	fmt.Println(usage_with_annotations.FileNotFound.Hint())
+	fmt.Println(usage_with_annotations.Internal.Hint())
+	fmt.Printf("%q\n", usage_with_annotations.NotFound.Hint())
+
+	err := usage_with_annotations.FileNotFound.OhNo("loading report.pdf", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+	fmt.Println(err)
+	// Output:
+	// check the path of the file
+	// try again once the status page is green
+	// ""
+	// [0x2]usage_with_annotations.FileNotFound: File was not found, loading report.pdf, hint: check the path of the file
+}
+
+// LocalizedDescription returns the description in a language, falling back
+// from de-CH to de and then to the description in English, and
+// [ohno.Localize] prints a whole error in that language.
+func ExampleStorageError_LocalizedDescription() {
+	fmt.Println(usage_with_annotations.FileNotFound.LocalizedDescription("de-CH"))
+	fmt.Println(usage_with_annotations.DeletedFileNotFound.LocalizedDescription("de-CH"))
+	fmt.Println(usage_with_annotations.Internal.LocalizedDescription("de-CH"))
+	fmt.Println(usage_with_annotations.NotFound.LocalizedDescription("fr"))
+
+	cause := usage_with_annotations.NotFound.OhNo("reading the index", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+	err := usage_with_annotations.FileNotFound.OhNo("loading report.pdf", nil, cause, sourceinfo.NoSourceInfo, time.Time{}, "")
+	fmt.Println(ohno.Localize(err, "de_CH"))
+	// Output:
+	// D Datei isch nöd gfunde worde
+	// Die Datei wurde gelöscht
+	// An internal error occurred
+	// Requested resource was not found
+	// [0x2]usage_with_annotations.FileNotFound: D Datei isch nöd gfunde worde, loading report.pdf, hint: check the path of the file
+	// -> [0x1]usage_with_annotations.NotFound: Die angeforderte Ressource wurde nicht gefunden, reading the index
+}
+
+// The constructor generated for a description with placeholders keeps the
+// description as the message template and the arguments apart, and prints the
+// filled in template.
+func ExampleQuotaExceededErr() {
+	err := usage_with_annotations.QuotaExceededErr("reports", 1024)
+
+	var o *ohno.OhNoError
+	if errors.As(err, &o) {
+		fmt.Println(o.MessageTemplate)
+		fmt.Println(o.MessageArgs)
+		fmt.Printf("%T\n", o.MessageArgs["Bytes"])
+	}
+	fmt.Println(errors.Is(err, usage_with_annotations.QuotaExceeded))
+	fmt.Println(err)
+	fmt.Println(ohno.Localize(err, "de"))
+	// Output:
+	// Quota of bucket {{.Bucket}} exceeded by {{.Bytes}} bytes
+	// map[Bucket:reports Bytes:1024]
+	// int64
+	// true
+	// [0x5]usage_with_annotations.QuotaExceeded: Quota of bucket reports exceeded by 1024 bytes
+	// [0x5]usage_with_annotations.QuotaExceeded: Kontingent des Buckets reports um 1024 Bytes überschritten
+}
@@ -13,7 +13,7 @@
 // [ohnogen]: https://pkg.go.dev/github.com/A-0-5/ohno/cmd/ohnogen
 package usage_with_ohno
 
-//go:generate go run ../../cmd/ohnogen/main.go -type=MyFabulousOhNoError -formatbase=16 -output=example_errors.go -ohno
+//go:generate go run ../../cmd/ohnogen -type=MyFabulousOhNoError -formatbase=16 -output=example_errors.go -ohno -options -parse
 
 // We first define a custom type like the one below
 type MyFabulousOhNoError int
@@ -26,12 +26,17 @@ type MyFabulousOhNoError int
 //
 // As this example purely concentrates on using the enums directly without
 // depending on the ohno package the -ohno flag is omitted
+//
+// Busy is annotated with //ohno:retryable so that a Retryable() method is
+// generated which tells [github.com/A-0-5/ohno/pkg/ohno.Retry] that it is worth
+// trying again when this error comes up
 const (
 	NotFound      MyFabulousOhNoError = 100 + iota // I didn't find what you were looking for!
 	AlreadyExists                                  // I have this already!
 	Internal                                       // Its not you, its me :(
 	Unknown                                        // I don't know what happened
-	Busy                                           // I'm busy rn, can we do this later?
-	Unauthorised                                   // You ain't got the creds to do this
-	Fatal                                          // Help!!! Im dying!!!
+	//ohno:retryable
+	Busy         // I'm busy rn, can we do this later?
+	Unauthorised // You ain't got the creds to do this
+	Fatal        // Help!!! Im dying!!!
 )
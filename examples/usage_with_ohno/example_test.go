@@ -6,6 +6,7 @@
 package usage_with_ohno_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -36,7 +37,7 @@ func ExampleMyFabulousOhNoError_usage() {
 	// We just print what ever foo returns
 	fmt.Println(Foo().Error())
 	// Output:
-	// 1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// 1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 }
 
 // This example demonstrates how an OhNoError can be checked directly against
@@ -58,7 +59,7 @@ func ExampleMyFabulousOhNoError_check() {
 
 	// Output:
 	// oh no its fatal!!!
-	// 	1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// 	1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 	// they match !!!
 }
 
@@ -87,7 +88,7 @@ func ExampleMyFabulousOhNoError_switch() {
 
 	// Output:
 	// oh no its fatal!!!
-	// 	1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// 	1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 }
 
 // By default the OhNo method allows you wrap an error inside an OhNoError when
@@ -127,7 +128,7 @@ func ExampleMyFabulousOhNoError_wrapUnwrap() {
 
 	// Output:
 	// 1970-01-01 00:05:00 [0x67]usage_with_ohno.Unknown: I don't know what happened, Ive got no clue as to what happened, 12345
-	// -> 1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// -> 1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 	// causeOfMyError is fooErr
 	// causeOfMyError is usage_with_ohno.Fatal
 }
@@ -157,7 +158,7 @@ func ExampleMyFabulousOhNoError_join() {
 
 	// Output:
 	// 1970-01-01 00:05:00 [0x66]usage_with_ohno.Internal: Its not you, its me :(, something went wrong, some data
-	// 1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// 1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 }
 
 // As a part of the structured representation goals of this package the
@@ -186,13 +187,14 @@ func ExampleMyFabulousOhNoError_marshal() {
 	// Output:
 	// json:
 	// {
+	//   "schema_version": "1",
 	//   "additional_info": {
 	//     "BadStuff": "some data here"
 	//   },
 	//   "source_information": {
 	//     "file": "example_test.go",
 	//     "function": "github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo",
-	//     "line": 23
+	//     "line": 24
 	//   },
 	//   "package": "usage_with_ohno",
 	//   "code": "0x6a",
@@ -203,12 +205,13 @@ func ExampleMyFabulousOhNoError_marshal() {
 	// }
 	//
 	// yaml:
+	// schema_version: "1"
 	// additional_info:
 	//     badstuff: some data here
 	// source_information:
 	//     file: example_test.go
 	//     function: github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo
-	//     line: 23
+	//     line: 24
 	// package: usage_with_ohno
 	// code: "0x6a"
 	// name: Fatal
@@ -217,6 +220,243 @@ func ExampleMyFabulousOhNoError_marshal() {
 	// timestamp: "1970-01-01 00:00:00"
 }
 
+// The json representation follows a versioned schema which is shipped with
+// the ohno package. Consumers of your errors can check a payload against it
+// using ohno.Validate.
+func ExampleMyFabulousOhNoError_validate() {
+	// Lets wrap a plain error from some dependency inside our error
+	myErr := usage_with_ohno.Internal.OhNo(
+		"could not read the config",
+		nil,
+		errors.New("open config.yaml: permission denied"),
+		sourceinfo.NoSourceInfo,
+		time.Time{},
+		"",
+	)
+
+	myJson, err := json.Marshal(myErr)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+
+	fmt.Println(string(myJson))
+	fmt.Println(ohno.Validate(myJson))
+
+	// A payload with a missing name and a code of the wrong type won't match
+	fmt.Println(ohno.Validate([]byte(`{"schema_version": "1", "package": "usage_with_ohno", "code": 102, "description": "Its not you, its me :("}`)))
+
+	// Output:
+	// {"schema_version":"1","caused_by":"open config.yaml: permission denied","package":"usage_with_ohno","code":"0x66","name":"Internal","message":"could not read the config","description":"Its not you, its me :("}
+	// <nil>
+	// payload does not match the ohno error schema version 1:
+	// $: missing property "name" required by "code"
+	// $.code: expected string but found integer
+}
+
+// Errors whose codes are annotated //ohno:retryable can be retried with
+// ohno.Retry, which gives up as soon as an error which is not retryable comes
+// up or the attempts run out
+func ExampleMyFabulousOhNoError_retry() {
+	attempts := 0
+	err := ohno.Retry(context.Background(), ohno.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}, func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return usage_with_ohno.Busy.OhNo("try again later", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+		}
+		return nil
+	})
+	fmt.Println(attempts, err)
+
+	// Unauthorised is not retryable so there is only a single attempt
+	attempts = 0
+	err = ohno.Retry(context.Background(), ohno.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}, func(ctx context.Context) error {
+		attempts++
+		return usage_with_ohno.Unauthorised.OhNo("who are you?", nil, nil, sourceinfo.NoSourceInfo, time.Time{}, "")
+	})
+	fmt.Println(attempts, err)
+
+	// The whole chain is looked at, a retryable code wrapped by fmt.Errorf is
+	// still retryable
+	fmt.Println(ohno.IsRetryable(fmt.Errorf("calling the backend: %w", usage_with_ohno.Busy)))
+	fmt.Println(ohno.IsRetryable(usage_with_ohno.Fatal))
+
+	// Output:
+	// 3 <nil>
+	// 1 [0x69]usage_with_ohno.Unauthorised: You ain't got the creds to do this, who are you?
+	// true
+	// false
+}
+
+// When only some parts of the error matter the With method, generated with
+// -options, takes just those as options instead of all the arguments of OhNo
+func ExampleMyFabulousOhNoError_with() {
+	err := usage_with_ohno.Internal.With(
+		ohno.WithMessage("could not reach the database"),
+		ohno.WithCause(usage_with_ohno.Busy),
+		ohno.WithTimestamp(time.Unix(0, 0).UTC(), time.DateTime),
+	)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, usage_with_ohno.Busy))
+
+	// Output:
+	// 1970-01-01 00:00:00 [0x66]usage_with_ohno.Internal: Its not you, its me :(, could not reach the database
+	// -> [0x68]usage_with_ohno.Busy: I'm busy rn, can we do this later?
+	// true
+}
+
+// Errors which are put together in several steps can be built with
+// ohno.Build, the source information is the one of the line calling Err
+func ExampleMyFabulousOhNoError_build() {
+	b := ohno.Build(usage_with_ohno.NotFound).
+		Msgf("user %d not found", 42).
+		Field("user", 42).
+		Field("region", "eu")
+	b.Cause(usage_with_ohno.Unauthorised)
+	err := b.Source(sourceinfo.ShortFileAndLine).
+		At(time.Unix(0, 0).UTC(), time.DateTime).
+		Err()
+	fmt.Println(err)
+
+	// Output:
+	// 1970-01-01 00:00:00 example_test.go:318: [0x64]usage_with_ohno.NotFound: I didn't find what you were looking for!, user 42 not found, user=42 region=eu
+	// -> [0x69]usage_with_ohno.Unauthorised: You ain't got the creds to do this
+}
+
+// The defaults for the parameters left at their zero value can be set once
+// with ohno.SetConfig, or for a narrower scope with a context carrying the
+// configuration
+func ExampleMyFabulousOhNoError_config() {
+	old := ohno.GetConfig()
+	defer ohno.SetConfig(old)
+
+	ohno.SetConfig(ohno.Config{
+		AutoTimestamp:   true,
+		TimestampLayout: time.DateTime,
+		Clock:           ohno.FixedClock(time.Unix(0, 0).UTC()),
+		MaxCauseDepth:   1,
+	})
+	err := usage_with_ohno.Internal.OhNo("", nil, usage_with_ohno.Busy.OhNo("", nil, usage_with_ohno.Unauthorised, sourceinfo.NoSourceInfo, time.Time{}, ""), sourceinfo.NoSourceInfo, time.Time{}, "")
+	fmt.Println(err)
+
+	ctx := ohno.WithConfig(context.Background(), ohno.Config{SourceInfoType: sourceinfo.ShortFileAndLine})
+	fmt.Println(ohno.Build(usage_with_ohno.NotFound).Context(ctx).Err())
+
+	// Output:
+	// 1970-01-01 00:00:00 [0x66]usage_with_ohno.Internal: Its not you, its me :(
+	// -> 1970-01-01 00:00:00 [0x68]usage_with_ohno.Busy: I'm busy rn, can we do this later?
+	// -> ...
+	// example_test.go:343: [0x64]usage_with_ohno.NotFound: I didn't find what you were looking for!
+}
+
+// The current time of the errors timestamped automatically comes from the
+// clock set with ohno.SetClock, a test would rather call ohno.UseClock(t, ...)
+// which restores the clock when the test completes
+func ExampleMyFabulousOhNoError_clock() {
+	restore := ohno.SetClock(ohno.FixedClock(time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)))
+	defer restore()
+
+	err := ohno.Build(usage_with_ohno.Busy).Now().Err()
+	fmt.Println(err)
+
+	// Output:
+	// 2023-10-01T12:00:00Z [0x68]usage_with_ohno.Busy: I'm busy rn, can we do this later?
+}
+
+// Errors of other packages get an error code with ohno.Wrap or ohno.Wrapf.
+// Wrapping an error in the code it already has adds the message to it instead
+// of nesting it once more
+func ExampleMyFabulousOhNoError_wrap() {
+	err := errors.New("open config.yaml: no such file or directory")
+	err = ohno.Wrap(err, usage_with_ohno.NotFound, "opening the config")
+	fmt.Println(err)
+
+	err = ohno.Wrapf(err, usage_with_ohno.NotFound, "starting %s", "the server")
+	fmt.Println(err)
+
+	fmt.Println(ohno.Wrap(nil, usage_with_ohno.NotFound, "nothing happened"))
+
+	// Output:
+	// [0x64]usage_with_ohno.NotFound: I didn't find what you were looking for!, opening the config
+	// -> open config.yaml: no such file or directory
+	// [0x64]usage_with_ohno.NotFound: I didn't find what you were looking for!, starting the server, opening the config
+	// -> open config.yaml: no such file or directory
+	// <nil>
+}
+
+// Calls to fmt.Errorf become calls to ohno.Errorf with an error code, the
+// errors wrapped by %w are the cause and can still be found by errors.Is. The
+// one wrapped by a trailing ": %w" is printed after the message rather than in
+// it
+func ExampleMyFabulousOhNoError_errorf() {
+	err := ohno.Errorf(usage_with_ohno.Internal, "loading %s: %w", "users", usage_with_ohno.Busy)
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, usage_with_ohno.Busy))
+
+	err = ohno.Errorf(usage_with_ohno.Internal, "syncing: %w and %w", usage_with_ohno.Busy, usage_with_ohno.Unauthorised)
+	fmt.Println(errors.Is(err, usage_with_ohno.Unauthorised))
+	fmt.Println(len(errors.Unwrap(err).(*ohno.OhNoJoinError).Errors))
+
+	// Output:
+	// [0x66]usage_with_ohno.Internal: Its not you, its me :(, loading users
+	// -> [0x68]usage_with_ohno.Busy: I'm busy rn, can we do this later?
+	// true
+	// true
+	// 2
+}
+
+// Errors which carry context but no error code of their own are built with
+// ohno.NewUncoded, they start with their message when printed and are
+// marshaled without the fields of the code
+func ExampleMyFabulousOhNoError_uncoded() {
+	err := ohno.NewUncoded("retrying the upload", map[string]int{"attempt": 2}, usage_with_ohno.Busy, sourceinfo.NoSourceInfo, 0, time.Time{}, "")
+	fmt.Println(err)
+	fmt.Println(errors.Is(err, usage_with_ohno.Busy))
+
+	b, _ := json.Marshal(err)
+	fmt.Println(string(b))
+	fmt.Println(ohno.Validate(b))
+
+	// Output:
+	// retrying the upload, map[attempt:2]
+	// -> [0x68]usage_with_ohno.Busy: I'm busy rn, can we do this later?
+	// true
+	// {"schema_version":"1","additional_info":{"attempt":2},"caused_by":"[0x68]usage_with_ohno.Busy: I'm busy rn, can we do this later?","message":"retrying the upload"}
+	// <nil>
+}
+
+// Fields add context to an error as keys with values, they keep the order in
+// which they have been added when printed and marshaled. ohno.Field finds a
+// field anywhere in the chain and ohno.FieldsOf merges the fields of the whole
+// chain, the outer errors winning
+func ExampleMyFabulousOhNoError_fields() {
+	cause := usage_with_ohno.NotFound.With(ohno.WithFields(ohno.String("user", "42"), ohno.String("table", "users")))
+	err := usage_with_ohno.Internal.With(
+		ohno.WithCause(cause),
+		ohno.WithFields(ohno.String("request", "req-1"), ohno.Duration("elapsed", 1500*time.Millisecond), ohno.String("user", "admin")),
+	)
+	fmt.Println(err)
+
+	user, ok := ohno.Field(cause, "user")
+	fmt.Println(user, ok)
+	fmt.Println(ohno.FieldsOf(err))
+
+	b, _ := json.Marshal(err.(*ohno.OhNoError).Fields)
+	fmt.Println(string(b))
+	y, _ := yaml.Marshal(err.(*ohno.OhNoError).Fields)
+	fmt.Print(string(y))
+
+	// Output:
+	// [0x66]usage_with_ohno.Internal: Its not you, its me :(, request=req-1 elapsed=1.5s user=admin
+	// -> [0x64]usage_with_ohno.NotFound: I didn't find what you were looking for!, user=42 table=users
+	// 42 true
+	// request=req-1 elapsed=1.5s user=admin table=users
+	// {"request":"req-1","elapsed":"1.5s","user":"admin"}
+	// request: req-1
+	// elapsed: 1.5s
+	// user: admin
+}
+
 // There may be cases where you may get an error with deep nesting and when
 // marshalling it into either text, json or yaml you may want to improve
 // readability by flattening out the structure. To do that you can take any
@@ -277,27 +517,30 @@ func ExampleMyFabulousOhNoError_convertToJoinError() {
 	// error is wrapped so all nested errors will be indented
 	// 1970-01-01 00:10:00 [0x67]usage_with_ohno.Unknown: I don't know what happened, this error wraps barErr, level-2 nesting
 	// -> 1970-01-01 00:05:00 [0x66]usage_with_ohno.Internal: Its not you, its me :(, this error wraps fooErr, level-1 nesting
-	// -> 1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// -> 1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 	//
 	// all nested errors are flattened, no indentation
 	// 1970-01-01 00:10:00 [0x67]usage_with_ohno.Unknown: I don't know what happened, this error wraps barErr, level-2 nesting
 	// 1970-01-01 00:05:00 [0x66]usage_with_ohno.Internal: Its not you, its me :(, this error wraps fooErr, level-1 nesting
-	// 1970-01-01 00:00:00 example_test.go:23 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
+	// 1970-01-01 00:00:00 example_test.go:24 (github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo): [0x6a]usage_with_ohno.Fatal: Help!!! Im dying!!!, something really bad happened, {BadStuff:some data here}
 	//
 	// ----
 	//
 	// json representation of nested error{
+	//   "schema_version": "1",
 	//   "additional_info": "level-2 nesting",
 	//   "caused_by": {
+	//     "schema_version": "1",
 	//     "additional_info": "level-1 nesting",
 	//     "caused_by": {
+	//       "schema_version": "1",
 	//       "additional_info": {
 	//         "BadStuff": "some data here"
 	//       },
 	//       "source_information": {
 	//         "file": "example_test.go",
 	//         "function": "github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo",
-	//         "line": 23
+	//         "line": 24
 	//       },
 	//       "package": "usage_with_ohno",
 	//       "code": "0x6a",
@@ -324,6 +567,7 @@ func ExampleMyFabulousOhNoError_convertToJoinError() {
 	// json representation of flattened error{
 	//   "errors": [
 	//     {
+	//       "schema_version": "1",
 	//       "additional_info": "level-2 nesting",
 	//       "package": "usage_with_ohno",
 	//       "code": "0x67",
@@ -333,6 +577,7 @@ func ExampleMyFabulousOhNoError_convertToJoinError() {
 	//       "timestamp": "1970-01-01 00:10:00"
 	//     },
 	//     {
+	//       "schema_version": "1",
 	//       "additional_info": "level-1 nesting",
 	//       "package": "usage_with_ohno",
 	//       "code": "0x66",
@@ -342,13 +587,14 @@ func ExampleMyFabulousOhNoError_convertToJoinError() {
 	//       "timestamp": "1970-01-01 00:05:00"
 	//     },
 	//     {
+	//       "schema_version": "1",
 	//       "additional_info": {
 	//         "BadStuff": "some data here"
 	//       },
 	//       "source_information": {
 	//         "file": "example_test.go",
 	//         "function": "github.com/A-0-5/ohno/examples/usage_with_ohno_test.Foo",
-	//         "line": 23
+	//         "line": 24
 	//       },
 	//       "package": "usage_with_ohno",
 	//       "code": "0x6a",
@@ -12,7 +12,7 @@
 // [ohnogen]: https://pkg.go.dev/github.com/A-0-5/ohno/cmd/ohnogen
 package usage_without_ohno
 
-//go:generate go run ../../cmd/ohnogen/main.go -type=MyFabulousError -formatbase=16 -output=example_errors.go
+//go:generate go run ../../cmd/ohnogen -type=MyFabulousError -formatbase=16 -output=example_errors.go -parse
 
 // We first define a custom type like the one below
 type MyFabulousError int
@@ -21,7 +21,7 @@ type MyFabulousError int
 // run the command. (here formatbase=16 will make all the codes print in hex
 // representation)
 //
-//	ohnogen -type=MyFabulousError -formatbase=16 -output=example_errors.go
+//	ohnogen -type=MyFabulousError -formatbase=16 -output=example_errors.go -parse
 //
 // As this example purely concentrates on using the enums directly without
 // depending on the ohno package the -ohno flag is omitted
@@ -1,4 +1,4 @@
-// Code generated by "ohnogen -type=MyFabulousError -formatbase=16 -output=example_errors.go"; DO NOT EDIT.
+// Code generated by "ohnogen -type=MyFabulousError -formatbase=16 -output=example_errors.go -parse"; DO NOT EDIT.
 
 package usage_without_ohno
 
@@ -60,3 +60,20 @@ func (i MyFabulousError) Package() string {
 func (i MyFabulousError) Code() string {
 	return "0x" + strconv.FormatInt(int64(i), 16)
 }
+
+var _MyFabulousError_byName = map[string]MyFabulousError{
+	"NotFound":      NotFound,
+	"AlreadyExists": AlreadyExists,
+	"Internal":      Internal,
+	"Unknown":       Unknown,
+	"Busy":          Busy,
+	"Unauthorised":  Unauthorised,
+	"Fatal":         Fatal,
+}
+
+// Returns the error code with the given name as returned by String(), or
+// one of its aliases, and whether there is one
+func ParseMyFabulousError(name string) (MyFabulousError, bool) {
+	i, ok := _MyFabulousError_byName[name]
+	return i, ok
+}
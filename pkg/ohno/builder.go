@@ -0,0 +1,142 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// Builder builds an error of [OhNoError] type step by step, like
+//
+//	err := ohno.Build(NotFound).
+//		Msgf("user %s not found", id).
+//		Field("user", id).
+//		Cause(cause).
+//		Source(sourceinfo.ShortFileAndLineWithFunc).
+//		Now().
+//		Err()
+//
+// Every method but Err and Build returns the builder itself so that the calls
+// can be chained. A Builder is not safe for concurrent use.
+type Builder struct {
+	ctx            context.Context
+	code           ohnoer.OhNoer
+	err            OhNoError
+	sourceInfoType sourceinfo.SourceInfoType
+	sourceSet      bool // Whether Source was called
+}
+
+// Build starts building an error with the error code ohnoer.
+func Build(ohnoer ohnoer.OhNoer) *Builder {
+	return &Builder{code: ohnoer}
+}
+
+// Msg sets the custom message of the error.
+func (b *Builder) Msg(message string) *Builder {
+	b.err.Message = message
+	return b
+}
+
+// Msgf sets the custom message of the error formatted according to format as
+// [fmt.Sprintf] does.
+func (b *Builder) Msgf(format string, args ...any) *Builder {
+	b.err.Message = fmt.Sprintf(format, args...)
+	return b
+}
+
+// Field adds the field key with its value to the error. Adding a key again
+// replaces its value, see [OhNoError.AddFields].
+func (b *Builder) Field(key string, value any) *Builder {
+	b.err.AddFields(KeyValue{Key: key, Value: value})
+	return b
+}
+
+// Fields adds the fields to the error, like
+//
+//	ohno.Build(NotFound).Fields(ohno.String("user", id), ohno.Int("attempt", n))
+func (b *Builder) Fields(kvs ...KeyValue) *Builder {
+	b.err.AddFields(kvs...)
+	return b
+}
+
+// Extra sets any additional data adding context to the error.
+func (b *Builder) Extra(extra any) *Builder {
+	b.err.Extra = extra
+	return b
+}
+
+// Cause sets the error which led to the error.
+func (b *Builder) Cause(cause error) *Builder {
+	b.err.Cause = cause
+	return b
+}
+
+// Source captures the source information of the place where Err or Build is
+// called in the format given by sourceInfoType instead of the one of the
+// [Config], [sourceinfo.NoSourceInfo] captures none.
+func (b *Builder) Source(sourceInfoType sourceinfo.SourceInfoType) *Builder {
+	b.sourceInfoType = sourceInfoType
+	b.sourceSet = true
+	return b
+}
+
+// At sets the time at which the error occurred and the layout in which it is
+// printed, [time.RFC3339Nano] if layout is empty.
+func (b *Builder) At(timestamp time.Time, layout string) *Builder {
+	b.err.Timestamp = timestamp
+	b.err.TimestampLayout = layout
+	return b
+}
+
+// Now sets the time at which the error occurred to the current time, by the
+// clock of the [Config].
+func (b *Builder) Now() *Builder {
+	return b.At(ConfigFrom(b.ctx).now(), "")
+}
+
+// Context builds the error with the [Config] carried by ctx, see
+// [WithConfig].
+func (b *Builder) Context(ctx context.Context) *Builder {
+	b.ctx = ctx
+	return b
+}
+
+// Err returns the error built. The source information, if requested with
+// Source, is the one of the line calling Err however many methods were
+// chained before.
+func (b *Builder) Err() error {
+	return b.build(sourceinfo.DefaultCallDepth + 1)
+}
+
+// Build is [Builder.Err] returning the [OhNoError] built, for callers which
+// need to change it further.
+func (b *Builder) Build() *OhNoError {
+	return b.build(sourceinfo.DefaultCallDepth + 1)
+}
+
+// build implements [Builder.Err] and [Builder.Build], callDepth is the same
+// as the one of [New].
+func (b *Builder) build(callDepth int) *OhNoError {
+	c := ConfigFrom(b.ctx)
+	sourceInfoType := b.sourceInfoType
+	if !b.sourceSet {
+		sourceInfoType = c.SourceInfoType
+	}
+	return c.completeWithSource(&OhNoError{
+		ErrorCode:       b.code,
+		Message:         b.err.Message,
+		Extra:           b.err.Extra,
+		Fields:          append(Fields(nil), b.err.Fields...),
+		Cause:           b.err.Cause,
+		Timestamp:       b.err.Timestamp,
+		TimestampLayout: b.err.TimestampLayout,
+	}, sourceInfoType, callDepth+1)
+}
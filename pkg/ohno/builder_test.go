@@ -0,0 +1,74 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"errors"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+func TestBuilder(t *testing.T) {
+	cause := errors.New("connection refused")
+	extra := map[string]int{"attempt": 2}
+
+	tests := []struct {
+		name  string
+		build func(b *ohno.Builder) (*ohno.OhNoError, int)
+	}{
+		{
+			name: "Err",
+			build: func(b *ohno.Builder) (*ohno.OhNoError, int) {
+				_, _, line, _ := runtime.Caller(0)
+				err := b.Err()
+				return err.(*ohno.OhNoError), line + 1
+			},
+		},
+		{
+			name: "Build",
+			build: func(b *ohno.Builder) (*ohno.OhNoError, int) {
+				_, _, line, _ := runtime.Caller(0)
+				o := b.Build()
+				return o, line + 1
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := ohno.Build(notFound).
+				Msg("user 42 not found").
+				Extra(extra).
+				Field("user", 42).
+				Cause(cause).
+				Source(sourceinfo.ShortFileAndLine)
+			o, line := tt.build(b)
+
+			if o.ErrorCode != error(notFound) {
+				t.Errorf("code %v, want %v", o.ErrorCode, notFound)
+			}
+			if o.Message != "user 42 not found" {
+				t.Errorf("message %q", o.Message)
+			}
+			if !reflect.DeepEqual(o.Extra, extra) {
+				t.Errorf("extra %v, want %v", o.Extra, extra)
+			}
+			if v, ok := ohno.Field(o, "user"); !ok || v != 42 {
+				t.Errorf("field user %v, %t", v, ok)
+			}
+			if o.Cause != cause {
+				t.Errorf("cause %v, want %v", o.Cause, cause)
+			}
+			if o.SourceInfo == nil || o.SourceInfo.File != "builder_test.go" || o.SourceInfo.Line != line {
+				t.Errorf("source %v, want builder_test.go:%d", o.SourceInfo, line)
+			}
+		})
+	}
+}
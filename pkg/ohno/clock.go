@@ -0,0 +1,69 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock tells the current time to the errors which get it automatically, see
+// [Config.AutoTimestamp] and [Builder.Now]. Replacing it makes the output of
+// Error() and of the marshalers predictable in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc turns a function into a [Clock].
+type ClockFunc func() time.Time
+
+// Now returns the time returned by the function.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// systemClock is the clock used when none is set, it tells the time of the
+// system.
+var systemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a [Clock] which always tells the time t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// clockHolder holds the clock set by [SetClock].
+type clockHolder struct {
+	clock Clock
+}
+
+var currentClock atomic.Pointer[clockHolder]
+
+// SetClock sets the clock used by the whole package unless the [Config] in
+// use has one, nil sets back the clock of the system. It returns a function
+// restoring the clock set before.
+func SetClock(c Clock) (restore func()) {
+	previous := currentClock.Swap(&clockHolder{clock: c})
+	return func() {
+		currentClock.Store(previous)
+	}
+}
+
+// UseClock sets the clock used by the whole package for the duration of a
+// test, the clock set before is restored when the test and its subtests
+// complete. tb is typically a *testing.T or *testing.B. Tests using it must
+// not run in parallel with tests depending on the clock.
+func UseClock(tb interface{ Cleanup(func()) }, c Clock) {
+	tb.Cleanup(SetClock(c))
+}
+
+// clock returns the clock set by [SetClock], or the clock of the system if
+// none has been set.
+func clock() Clock {
+	if h := currentClock.Load(); h != nil && h.clock != nil {
+		return h.clock
+	}
+	return systemClock
+}
@@ -0,0 +1,105 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+)
+
+// clockTime returns the time told to an error timestamped automatically
+// with the configuration c.
+func clockTime(c ohno.Config) time.Time {
+	c.AutoTimestamp = true
+	return ohno.Build(notFound).Context(ohno.WithConfig(context.Background(), c)).Build().Timestamp
+}
+
+func TestSetClockRestore(t *testing.T) {
+	t1, t2, t3 := time.Unix(1, 0), time.Unix(2, 0), time.Unix(3, 0)
+
+	restore1 := ohno.SetClock(ohno.FixedClock(t1))
+	restore2 := ohno.SetClock(ohno.FixedClock(t2))
+	restore3 := ohno.SetClock(ohno.FixedClock(t3))
+	for _, step := range []struct {
+		restore func()
+		want    time.Time
+	}{
+		{restore: restore3, want: t2},
+		{restore: restore2, want: t1},
+	} {
+		step.restore()
+		if got := clockTime(ohno.Config{}); !got.Equal(step.want) {
+			t.Errorf("clock tells %s after restoring, want %s", got, step.want)
+		}
+	}
+
+	restore1()
+	if got := clockTime(ohno.Config{}); got.Before(time.Now().Add(-time.Hour)) {
+		t.Errorf("clock tells %s after restoring all, want the time of the system", got)
+	}
+}
+
+// cleanups runs the functions registered with Cleanup in reverse order, like
+// a *testing.T does.
+type cleanups []func()
+
+func (c *cleanups) Cleanup(f func()) { *c = append(*c, f) }
+
+func (c cleanups) run() {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i]()
+	}
+}
+
+func TestUseClock(t *testing.T) {
+	t1, t2 := time.Unix(1, 0), time.Unix(2, 0)
+
+	var outer cleanups
+	ohno.UseClock(&outer, ohno.FixedClock(t1))
+
+	var inner cleanups
+	ohno.UseClock(&inner, ohno.FixedClock(t2))
+	if got := clockTime(ohno.Config{}); !got.Equal(t2) {
+		t.Errorf("clock tells %s, want %s", got, t2)
+	}
+
+	inner.run()
+	if got := clockTime(ohno.Config{}); !got.Equal(t1) {
+		t.Errorf("clock tells %s after the inner cleanup, want %s", got, t1)
+	}
+
+	outer.run()
+	if got := clockTime(ohno.Config{}); got.Before(time.Now().Add(-time.Hour)) {
+		t.Errorf("clock tells %s after the outer cleanup, want the time of the system", got)
+	}
+
+	t.Run("subtest", func(t *testing.T) {
+		ohno.UseClock(t, ohno.FixedClock(t2))
+	})
+	if got := clockTime(ohno.Config{}); got.Before(time.Now().Add(-time.Hour)) {
+		t.Errorf("clock tells %s after the subtest, want the time of the system", got)
+	}
+}
+
+func TestConfigClock(t *testing.T) {
+	global, configured := time.Unix(1, 0), time.Unix(2, 0)
+	ohno.UseClock(t, ohno.FixedClock(global))
+
+	if got := clockTime(ohno.Config{Clock: ohno.FixedClock(configured)}); !got.Equal(configured) {
+		t.Errorf("clock of the configuration tells %s, want %s", got, configured)
+	}
+	if got := clockTime(ohno.Config{}); !got.Equal(global) {
+		t.Errorf("clock without one in the configuration tells %s, want %s", got, global)
+	}
+
+	ohno.UseClock(t, nil)
+	if got := clockTime(ohno.Config{Clock: ohno.FixedClock(configured)}); !got.Equal(configured) {
+		t.Errorf("clock of the configuration tells %s with the system clock set, want %s", got, configured)
+	}
+}
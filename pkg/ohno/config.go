@@ -0,0 +1,149 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// Config holds the defaults used when building errors, whenever the caller
+// passes the zero value of a parameter. The zero value of each field keeps
+// the behavior of the package without a configuration.
+type Config struct {
+	// Format of the source information captured when the caller passes
+	// [sourceinfo.NoSourceInfo]. [WithSource] and [Builder.Source] override
+	// it for a single error, [sourceinfo.NoSourceInfo] included
+	SourceInfoType sourceinfo.SourceInfoType
+	// Whether errors built without a timestamp get the current time
+	AutoTimestamp bool
+	// Layout of the timestamps built without one, [time.RFC3339Nano] by
+	// default
+	TimestampLayout string
+	// Tells the current time for AutoTimestamp, the clock set by [SetClock]
+	// by default
+	Clock Clock
+	// Number of nested causes of an [OhNoError] printed by Error() and
+	// marshaled, the deeper ones are left out. All of them by default. The
+	// value of the configuration the error was built with applies, the
+	// smallest one if the causes have been built with smaller values
+	MaxCauseDepth int
+}
+
+// omittedCauses takes the place of the causes left out because of
+// [Config.MaxCauseDepth].
+const omittedCauses = "..."
+
+// config holds the configuration set by [SetConfig].
+var config atomic.Pointer[Config]
+
+// SetConfig sets the configuration used by the whole package. It is meant to
+// be called once at startup, use [WithConfig] for a configuration in a
+// narrower scope.
+func SetConfig(c Config) {
+	config.Store(&c)
+}
+
+// GetConfig returns the configuration set by [SetConfig], or the zero value
+// if none has been set.
+func GetConfig() Config {
+	if c := config.Load(); c != nil {
+		return *c
+	}
+	return Config{}
+}
+
+// configKey is the key of the configuration stored in a context.
+type configKey struct{}
+
+// WithConfig returns a copy of ctx carrying the configuration c, which is
+// used instead of the one set by [SetConfig] by the errors built with this
+// context through [WithContext] or [Builder.Context].
+func WithConfig(ctx context.Context, c Config) context.Context {
+	return context.WithValue(ctx, configKey{}, c)
+}
+
+// ConfigFrom returns the configuration carried by ctx, or the one set by
+// [SetConfig] if ctx is nil or carries none.
+func ConfigFrom(ctx context.Context) Config {
+	if ctx != nil {
+		if c, ok := ctx.Value(configKey{}).(Config); ok {
+			return c
+		}
+	}
+	return GetConfig()
+}
+
+// now returns the current time by the clock of the configuration.
+func (c Config) now() time.Time {
+	if c.Clock != nil {
+		return c.Clock.Now()
+	}
+	return clock().Now()
+}
+
+// allCauses is the budget of levels of causes of an error printed or
+// marshaled on its own, the limits of the errors along the way apply.
+const allCauses = -1
+
+// causeBudget returns the number of levels of causes printed and marshaled
+// below o, given the budget left by the errors o is nested in: the smaller of
+// it and the MaxCauseDepth of the configuration o was built with, or of the
+// one set by [SetConfig] if o was built as a struct literal. A negative
+// budget allows all of them.
+func (o *OhNoError) causeBudget(budget int) int {
+	var maxDepth int
+	if o.config != nil {
+		maxDepth = o.config.MaxCauseDepth
+	} else {
+		maxDepth = GetConfig().MaxCauseDepth
+	}
+	if maxDepth > 0 && (budget < 0 || maxDepth < budget) {
+		return maxDepth
+	}
+	return budget
+}
+
+// nestedBudget returns the budget left to the cause of an error whose budget
+// is budget, which must not be 0.
+func nestedBudget(budget int) int {
+	if budget < 0 {
+		return budget
+	}
+	return budget - 1
+}
+
+// complete fills in the parts of o left empty from the configuration and
+// captures the source information callDepth frames up as [New] does.
+func (c Config) complete(o *OhNoError, sourceInfoType sourceinfo.SourceInfoType, callDepth int) *OhNoError {
+	if sourceInfoType == sourceinfo.NoSourceInfo {
+		sourceInfoType = c.SourceInfoType
+	}
+	return c.completeWithSource(o, sourceInfoType, callDepth+1)
+}
+
+// completeWithSource is complete with the source information captured in the
+// format given by sourceInfoType, none for [sourceinfo.NoSourceInfo] whatever
+// the configuration.
+func (c Config) completeWithSource(o *OhNoError, sourceInfoType sourceinfo.SourceInfoType, callDepth int) *OhNoError {
+	o.SourceInfo = sourceinfo.GetSourceInformation(callDepth+1, sourceInfoType)
+	o.config = &c
+
+	if o.Timestamp.IsZero() && c.AutoTimestamp {
+		o.Timestamp = c.now()
+	}
+	if !o.Timestamp.IsZero() && o.TimestampLayout == "" {
+		o.TimestampLayout = c.TimestampLayout
+		if o.TimestampLayout == "" {
+			o.TimestampLayout = time.RFC3339Nano
+		}
+	}
+
+	return o
+}
@@ -0,0 +1,164 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+func TestConfigZeroValue(t *testing.T) {
+	defer ohno.SetConfig(ohno.GetConfig())
+	ohno.SetConfig(ohno.Config{})
+	ohno.UseClock(t, ohno.FixedClock(time.Unix(0, 0).UTC()))
+
+	tests := []struct {
+		name   string
+		err    *ohno.OhNoError
+		source bool      // whether source information is captured
+		time   time.Time // timestamp of the error
+		layout string
+	}{
+		{
+			name: "no source information nor timestamp",
+			err:  ohno.Build(notFound).Build(),
+		},
+		{
+			name:   "given source information",
+			err:    ohno.Build(notFound).Source(sourceinfo.ShortFileAndLine).Build(),
+			source: true,
+		},
+		{
+			name:   "timestamp in the default layout",
+			err:    ohno.Build(notFound).At(time.Unix(60, 0).UTC(), "").Build(),
+			time:   time.Unix(60, 0).UTC(),
+			layout: time.RFC3339Nano,
+		},
+		{
+			name:   "automatic timestamp by the global clock",
+			err:    ohno.Build(notFound).Context(ohno.WithConfig(context.Background(), ohno.Config{AutoTimestamp: true})).Build(),
+			time:   time.Unix(0, 0).UTC(),
+			layout: time.RFC3339Nano,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.SourceInfo != nil; got != tt.source {
+				t.Errorf("source information captured %t, want %t", got, tt.source)
+			}
+			if !tt.err.Timestamp.Equal(tt.time) || tt.err.TimestampLayout != tt.layout {
+				t.Errorf("timestamp %s in the layout %q, want %s in %q", tt.err.Timestamp, tt.err.TimestampLayout, tt.time, tt.layout)
+			}
+		})
+	}
+
+	// Every cause is printed.
+	var err error = notFound
+	for i := 0; i < 10; i++ {
+		err = ohno.Build(internal).Cause(err).Err()
+	}
+	if got := strings.Count(err.Error(), "\n"); got != 10 || strings.Contains(err.Error(), "...") {
+		t.Errorf("%d causes printed, want 10:\n%s", got, err)
+	}
+}
+
+// chain returns an error with n levels of causes below it, each built with
+// the configuration of ctx.
+func chain(ctx context.Context, n int) error {
+	var err error = notFound
+	for i := 0; i < n; i++ {
+		err = ohno.Build(internal).Context(ctx).Cause(err).Err()
+	}
+	return err
+}
+
+func TestMaxCauseDepth(t *testing.T) {
+	defer ohno.SetConfig(ohno.GetConfig())
+	ohno.SetConfig(ohno.Config{})
+
+	limited := ohno.WithConfig(context.Background(), ohno.Config{MaxCauseDepth: 2})
+	tests := []struct {
+		name  string
+		err   func() error
+		lines int // lines printed, one per error
+		cut   bool
+	}{
+		{
+			name:  "configuration of the context",
+			err:   func() error { return chain(limited, 4) },
+			lines: 3,
+			cut:   true,
+		},
+		{
+			name:  "within the limit",
+			err:   func() error { return chain(limited, 2) },
+			lines: 3,
+		},
+		{
+			name: "configuration set after building",
+			err: func() error {
+				err := chain(context.Background(), 4)
+				ohno.SetConfig(ohno.Config{MaxCauseDepth: 1})
+				return err
+			},
+			lines: 5,
+		},
+		{
+			name: "stricter cause",
+			err: func() error {
+				inner := ohno.Build(internal).Context(ohno.WithConfig(context.Background(), ohno.Config{MaxCauseDepth: 1})).Cause(chain(context.Background(), 3)).Err()
+				return ohno.Build(internal).Cause(inner).Err()
+			},
+			lines: 3,
+			cut:   true,
+		},
+		{
+			name: "struct literal",
+			err: func() error {
+				ohno.SetConfig(ohno.Config{MaxCauseDepth: 1})
+				return &ohno.OhNoError{ErrorCode: internal, Cause: &ohno.OhNoError{ErrorCode: internal, Cause: notFound}}
+			},
+			lines: 2,
+			cut:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer ohno.SetConfig(ohno.Config{})
+			err := tt.err()
+
+			printed := err.Error()
+			lines := strings.Count(printed, "\n") + 1
+			if tt.cut {
+				lines--
+			}
+			if lines != tt.lines || strings.HasSuffix(printed, "...") != tt.cut {
+				t.Errorf("printed\n%s\nwant %d errors, cut %t", printed, tt.lines, tt.cut)
+			}
+
+			b, jsonErr := json.Marshal(err)
+			if jsonErr != nil {
+				t.Fatal(jsonErr)
+			}
+			// Every error but the last has a cause, the causes left out too.
+			causes := tt.lines - 1
+			if tt.cut {
+				causes++
+			}
+			if strings.Count(string(b), `"caused_by"`) != causes || strings.Contains(string(b), `"caused_by":"..."`) != tt.cut {
+				t.Errorf("marshaled %s\nwant %d errors, cut %t", b, tt.lines, tt.cut)
+			}
+		})
+	}
+}
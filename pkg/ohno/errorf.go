@@ -0,0 +1,73 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// Errorf generates a new error of [OhNoError] type with the error code ohnoer
+// and the message formatted according to format as [fmt.Errorf] does, so that
+//
+//	fmt.Errorf("loading %s: %w", name, err)
+//
+// becomes
+//
+//	ohno.Errorf(NotFound, "loading %s: %w", name, err)
+//
+// The error wrapped by a %w verb is the cause, several errors wrapped by
+// several %w verbs are the errors of an [OhNoJoinError] which is the cause.
+// As Error() prints the cause after the message, a trailing ": %w" is left out
+// of the message, so the message of the call above is the one of
+// fmt.Sprintf("loading %s", name). The %w verbs anywhere else are formatted
+// as fmt.Errorf does, so the wrapped errors are in the message as well. The
+// source information, the timestamp and its layout are the ones of the
+// [Config] set by [SetConfig], the source information is the one of the line
+// calling Errorf.
+func Errorf(ohnoer ohnoer.OhNoer, format string, args ...any) error {
+	formatted := fmt.Errorf(format, args...)
+
+	var cause error
+	switch wrapped := formatted.(type) {
+	case interface{ Unwrap() error }:
+		cause = wrapped.Unwrap()
+	case interface{ Unwrap() []error }:
+		cause = &OhNoJoinError{Errors: wrapped.Unwrap()}
+	}
+
+	message := formatted.Error()
+	if prefix, ok := withoutWrappedSuffix(format); ok && cause != nil {
+		// A precision of 0 consumes the operand of the %w verb and prints
+		// nothing, the other %w verbs are formatted by fmt.Errorf.
+		message = fmt.Errorf(prefix+"%.0s", args...).Error()
+	}
+
+	return GetConfig().complete(&OhNoError{
+		ErrorCode: ohnoer,
+		Message:   message,
+		Cause:     cause,
+	}, sourceinfo.NoSourceInfo, sourceinfo.DefaultCallDepth+1)
+}
+
+// wrappedSuffix ends the formats of [Errorf] whose last wrapped error is
+// left out of the message.
+const wrappedSuffix = ": %w"
+
+// withoutWrappedSuffix returns format without the wrappedSuffix it ends with
+// and true, or false if it does not end with one. A percent sign before the
+// colon is only part of the format if it is escaped.
+func withoutWrappedSuffix(format string) (string, bool) {
+	prefix := strings.TrimSuffix(format, wrappedSuffix)
+	if prefix == format {
+		return format, false
+	}
+	percents := len(prefix) - len(strings.TrimRight(prefix, "%"))
+	return prefix, percents%2 == 0
+}
@@ -0,0 +1,123 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+)
+
+func TestErrorf(t *testing.T) {
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	tests := []struct {
+		name    string
+		format  string
+		args    []any
+		message string
+		causes  []error // errors the result must wrap
+	}{
+		{
+			name:    "no wrapped error",
+			format:  "loading %s: %v",
+			args:    []any{"users", errA},
+			message: "loading users: a failed",
+		},
+		{
+			name:    "wrapped error",
+			format:  "loading %s: %w",
+			args:    []any{"users", errA},
+			message: "loading users",
+			causes:  []error{errA},
+		},
+		{
+			name:    "wrapped error in the middle",
+			format:  "loading %w from %s",
+			args:    []any{errA, "disk"},
+			message: "loading a failed from disk",
+			causes:  []error{errA},
+		},
+		{
+			name:    "leading wrapped error",
+			format:  "%w: loading %s",
+			args:    []any{errA, "users"},
+			message: "a failed: loading users",
+			causes:  []error{errA},
+		},
+		{
+			name:    "wrapped error in parentheses",
+			format:  "loading (%w) now",
+			args:    []any{errA},
+			message: "loading (a failed) now",
+			causes:  []error{errA},
+		},
+		{
+			name:    "several wrapped errors",
+			format:  "syncing %d items: %w: %w",
+			args:    []any{3, errA, errB},
+			message: "syncing 3 items: a failed",
+			causes:  []error{errA, errB},
+		},
+		{
+			name:    "several wrapped errors in a list",
+			format:  "syncing %d items: %w, %w",
+			args:    []any{3, errA, errB},
+			message: "syncing 3 items: a failed, b failed",
+			causes:  []error{errA, errB},
+		},
+		{
+			name:    "argument index",
+			format:  "loading %[2]s for %[3]s: %w",
+			args:    []any{"unused", "users", "admins", errA},
+			message: "loading users for admins",
+			causes:  []error{errA},
+		},
+		{
+			name:    "percent sign",
+			format:  "100%%: %w",
+			args:    []any{errA},
+			message: "100%",
+			causes:  []error{errA},
+		},
+		{
+			name:    "nil wrapped error",
+			format:  "loading: %w",
+			args:    []any{nil},
+			message: "loading: %!w(<nil>)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ohno.Errorf(internal, tt.format, tt.args...)
+
+			var o *ohno.OhNoError
+			if !errors.As(err, &o) {
+				t.Fatalf("%T is not an OhNoError", err)
+			}
+			if o.Message != tt.message {
+				t.Errorf("message %q, want %q", o.Message, tt.message)
+			}
+			if len(tt.causes) == 0 && o.Cause != nil {
+				t.Errorf("cause %v, want none", o.Cause)
+			}
+			for _, cause := range tt.causes {
+				if !errors.Is(err, cause) {
+					t.Errorf("%v does not wrap %v", err, cause)
+				}
+				// The cause is printed after the message, which may hold it
+				// as well.
+				want := 1 + strings.Count(tt.message, cause.Error())
+				if n := strings.Count(err.Error(), cause.Error()); n != want {
+					t.Errorf("%q holds %q %d times, want %d", err, cause, n, want)
+				}
+			}
+		})
+	}
+}
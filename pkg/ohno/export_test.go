@@ -0,0 +1,46 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"context"
+	"time"
+)
+
+// ParseSchema exposes parseSchema to the tests.
+var ParseSchema = parseSchema
+
+// MaxParsedTemplates exposes maxParsedTemplates to the tests.
+const MaxParsedTemplates = maxParsedTemplates
+
+// ParsedTemplates returns the number of cached message templates.
+func ParsedTemplates() int {
+	parsedTemplatesMu.Lock()
+	defer parsedTemplatesMu.Unlock()
+	return len(parsedTemplates)
+}
+
+// SetRetryHooks replaces the functions [Retry] waits and randomizes the
+// backoff with, nil keeping the current one. It returns a function restoring
+// them.
+func SetRetryHooks(waitFunc func(ctx context.Context, d time.Duration) error, random func() float64) (restore func()) {
+	savedWait, savedRandom := wait, jitterRand
+	if waitFunc != nil {
+		wait = waitFunc
+	}
+	if random != nil {
+		jitterRand = random
+	}
+	return func() {
+		wait, jitterRand = savedWait, savedRandom
+	}
+}
+
+// Backoff returns the time [Retry] waits after the given number of failed
+// attempts.
+func Backoff(p RetryPolicy, failures int) time.Duration {
+	return p.withDefaults().backoff(failures)
+}
@@ -0,0 +1,210 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// KeyValue is a field adding context to an error, like the id of the user
+// who could not be found.
+type KeyValue struct {
+	Key   string
+	Value any
+}
+
+// String returns a field with a string value.
+func String(key, value string) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Int returns a field with an int value.
+func Int(key string, value int) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Int64 returns a field with an int64 value.
+func Int64(key string, value int64) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Uint64 returns a field with a uint64 value.
+func Uint64(key string, value uint64) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Float64 returns a field with a float64 value.
+func Float64(key string, value float64) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Bool returns a field with a bool value.
+func Bool(key string, value bool) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Duration returns a field with a [time.Duration] value, printed and
+// marshaled like "1.5s".
+func Duration(key string, value time.Duration) KeyValue {
+	return KeyValue{Key: key, Value: value.String()}
+}
+
+// Time returns a field with a [time.Time] value, printed and marshaled in the
+// [time.RFC3339Nano] layout.
+func Time(key string, value time.Time) KeyValue {
+	return KeyValue{Key: key, Value: value.Format(time.RFC3339Nano)}
+}
+
+// Any returns a field with a value of any type.
+func Any(key string, value any) KeyValue {
+	return KeyValue{Key: key, Value: value}
+}
+
+// Fields are the fields of an error in the order they have been added. They
+// are printed by Error() as key=value pairs and marshaled as an object with
+// the keys in that order.
+type Fields []KeyValue
+
+// Get returns the value of the field with the key and whether there is one.
+func (f Fields) Get(key string) (any, bool) {
+	for _, kv := range f {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return nil, false
+}
+
+// set returns a copy of the fields with the ones of kvs set. A key already
+// present keeps its place and gets the new value, a new key is added at the
+// end. f is never modified since its backing array may be shared with the
+// fields of other errors.
+func (f Fields) set(kvs ...KeyValue) Fields {
+	if len(kvs) == 0 {
+		return f
+	}
+
+	result := make(Fields, len(f), len(f)+len(kvs))
+	copy(result, f)
+	for _, kv := range kvs {
+		found := false
+		for i := range result {
+			if result[i].Key == kv.Key {
+				result[i].Value = kv.Value
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, kv)
+		}
+	}
+	return result
+}
+
+// String prints the fields as space separated key=value pairs, values with
+// spaces or quotes are quoted.
+func (f Fields) String() string {
+	pairs := make([]string, len(f))
+	for i, kv := range f {
+		value := fmt.Sprintf("%+v", kv.Value)
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		pairs[i] = kv.Key + "=" + value
+	}
+	return strings.Join(pairs, separator)
+}
+
+// A json marshaler implementation marshaling the fields as an object with the
+// keys in order
+func (f Fields) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, kv := range f {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(kv.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// A yaml marshaler implementation marshaling the fields as a mapping with the
+// keys in order
+func (f Fields) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, kv := range f {
+		value := new(yaml.Node)
+		if err := value.Encode(kv.Value); err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: kv.Key}, value)
+	}
+	return node, nil
+}
+
+// AddFields sets the fields of the error. A key already present keeps its
+// place and gets the new value, a new key is added at the end. It returns the
+// error so that it can be used like
+//
+//	return err.(*ohno.OhNoError).AddFields(ohno.String("user", id))
+func (o *OhNoError) AddFields(kvs ...KeyValue) *OhNoError {
+	o.Fields = o.Fields.set(kvs...)
+	return o
+}
+
+// Field returns the value of the field with the key of the outermost
+// [OhNoError] in the tree of err which has it, looking at the errors in the
+// order [errors.Is] would.
+func Field(err error, key string) (any, bool) {
+	var value any
+	found := false
+	walk(err, func(e error) bool {
+		if o, ok := e.(*OhNoError); ok && o != nil {
+			value, found = o.Fields.Get(key)
+		}
+		return !found
+	})
+	return value, found
+}
+
+// FieldsOf returns the fields of all the errors of type [OhNoError] in the
+// tree of err merged, looking at the errors in the order [errors.Is] would.
+// When several errors have a field with the same key the one of the outermost
+// error wins, the fields of the outer errors come first.
+func FieldsOf(err error) Fields {
+	var merged Fields
+	walk(err, func(e error) bool {
+		if o, ok := e.(*OhNoError); ok && o != nil {
+			for _, kv := range o.Fields {
+				if _, ok := merged.Get(kv.Key); !ok {
+					merged = append(merged, kv)
+				}
+			}
+		}
+		return true
+	})
+	return merged
+}
@@ -0,0 +1,197 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+)
+
+func TestAddFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields ohno.Fields
+		add    []ohno.KeyValue
+		want   ohno.Fields
+	}{
+		{
+			name: "empty",
+			add:  []ohno.KeyValue{ohno.Int("user", 42), ohno.String("path", "/tmp")},
+			want: ohno.Fields{ohno.Int("user", 42), ohno.String("path", "/tmp")},
+		},
+		{
+			name:   "new key at the end",
+			fields: ohno.Fields{ohno.Int("user", 42)},
+			add:    []ohno.KeyValue{ohno.Int("attempt", 2)},
+			want:   ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 2)},
+		},
+		{
+			name:   "replaced key keeps its place",
+			fields: ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 1), ohno.String("path", "/tmp")},
+			add:    []ohno.KeyValue{ohno.Int("attempt", 2)},
+			want:   ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 2), ohno.String("path", "/tmp")},
+		},
+		{
+			name:   "same key twice",
+			fields: ohno.Fields{ohno.Int("user", 42)},
+			add:    []ohno.KeyValue{ohno.Int("attempt", 1), ohno.Int("attempt", 2)},
+			want:   ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 2)},
+		},
+		{
+			name:   "nothing",
+			fields: ohno.Fields{ohno.Int("user", 42)},
+			want:   ohno.Fields{ohno.Int("user", 42)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &ohno.OhNoError{ErrorCode: notFound, Fields: tt.fields}
+			if got := o.AddFields(tt.add...); got != o {
+				t.Errorf("AddFields returned %p, want %p", got, o)
+			}
+			if !reflect.DeepEqual(o.Fields, tt.want) {
+				t.Errorf("fields %v, want %v", o.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddFieldsCopyOnWrite(t *testing.T) {
+	t.Run("replaced value", func(t *testing.T) {
+		shared := ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 1)}
+		a := &ohno.OhNoError{ErrorCode: notFound, Fields: shared}
+		b := &ohno.OhNoError{ErrorCode: notFound, Fields: shared}
+
+		a.AddFields(ohno.Int("attempt", 2))
+
+		want := ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 1)}
+		if !reflect.DeepEqual(b.Fields, want) {
+			t.Errorf("fields of the other error %v, want %v", b.Fields, want)
+		}
+		if !reflect.DeepEqual(shared, want) {
+			t.Errorf("shared fields %v, want %v", shared, want)
+		}
+	})
+
+	t.Run("spare capacity", func(t *testing.T) {
+		shared := make(ohno.Fields, 1, 4)
+		shared[0] = ohno.Int("user", 42)
+		a := &ohno.OhNoError{ErrorCode: notFound, Fields: shared}
+		b := &ohno.OhNoError{ErrorCode: notFound, Fields: shared}
+
+		a.AddFields(ohno.Int("attempt", 1))
+		b.AddFields(ohno.String("path", "/tmp"))
+
+		want := ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 1)}
+		if !reflect.DeepEqual(a.Fields, want) {
+			t.Errorf("fields %v, want %v", a.Fields, want)
+		}
+	})
+
+	t.Run("join error", func(t *testing.T) {
+		o := ohno.Build(notFound).Field("user", 42).Build()
+		joined := ohno.ConvertToJoinError(o).(*ohno.OhNoJoinError)
+
+		o.AddFields(ohno.Int("user", 7))
+
+		want := ohno.Fields{ohno.Int("user", 42)}
+		if got := joined.Errors[0].(*ohno.OhNoError).Fields; !reflect.DeepEqual(got, want) {
+			t.Errorf("fields of the joined error %v, want %v", got, want)
+		}
+	})
+}
+
+func TestFieldsOf(t *testing.T) {
+	inner := ohno.Build(internal).
+		Fields(ohno.String("path", "/tmp"), ohno.Int("user", 1)).
+		Build()
+	outer := ohno.Build(notFound).
+		Fields(ohno.Int("user", 42), ohno.Int("attempt", 2)).
+		Cause(inner).
+		Build()
+	other := ohno.Build(internal).
+		Fields(ohno.Int("attempt", 3), ohno.String("host", "db")).
+		Build()
+
+	tests := []struct {
+		name string
+		err  error
+		want ohno.Fields
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "foreign error",
+			err:  errors.New("connection refused"),
+		},
+		{
+			name: "single error",
+			err:  inner,
+			want: ohno.Fields{ohno.String("path", "/tmp"), ohno.Int("user", 1)},
+		},
+		{
+			name: "outermost wins",
+			err:  outer,
+			want: ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 2), ohno.String("path", "/tmp")},
+		},
+		{
+			name: "join",
+			err:  ohno.Join(outer, other),
+			want: ohno.Fields{ohno.Int("user", 42), ohno.Int("attempt", 2), ohno.String("path", "/tmp"), ohno.String("host", "db")},
+		},
+		{
+			name: "join in the cause",
+			err:  ohno.Build(notFound).Field("attempt", 1).Cause(ohno.Join(other, inner)).Build(),
+			want: ohno.Fields{ohno.Int("attempt", 1), ohno.String("host", "db"), ohno.String("path", "/tmp"), ohno.Int("user", 1)},
+		},
+		{
+			name: "errorf with several causes",
+			err:  ohno.Errorf(notFound, "lookup: %w, %w", other, inner),
+			want: ohno.Fields{ohno.Int("attempt", 3), ohno.String("host", "db"), ohno.String("path", "/tmp"), ohno.Int("user", 1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ohno.FieldsOf(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldsOf %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestField(t *testing.T) {
+	inner := ohno.Build(internal).Field("user", 1).Field("path", "/tmp").Build()
+	outer := ohno.Build(notFound).Field("user", 42).Cause(inner).Build()
+
+	tests := []struct {
+		name  string
+		err   error
+		key   string
+		want  any
+		found bool
+	}{
+		{name: "outermost", err: outer, key: "user", want: 42, found: true},
+		{name: "deeper", err: outer, key: "path", want: "/tmp", found: true},
+		{name: "missing", err: outer, key: "host"},
+		{name: "join", err: ohno.Join(errors.New("timeout"), inner, outer), key: "user", want: 1, found: true},
+		{name: "nil", key: "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := ohno.Field(tt.err, tt.key)
+			if got != tt.want || found != tt.found {
+				t.Errorf("Field(%q) = %v, %t, want %v, %t", tt.key, got, found, tt.want, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"strings"
+
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+)
+
+// Localize prints err the same way its Error() method does, but with the
+// descriptions of the error codes in the language given by the BCP 47 tag
+// lang like "de" or "de-CH". The causes of an [OhNoError] and the errors of
+// an [OhNoJoinError] are printed in that language as well. Error codes
+// generated by ohnogen with descriptions in other languages have a
+// LocalizedDescription method which decides the fallback when there is no
+// description in lang, any other error is printed as it is.
+func Localize(err error, lang string) string {
+	return localize(err, lang, allCauses)
+}
+
+// localize prints err as [Localize] does, budget is the number of levels of
+// causes the errors err is nested in allow.
+func localize(err error, lang string, budget int) string {
+	switch e := err.(type) {
+	case nil:
+		return ""
+	case *OhNoError:
+		if e == nil {
+			return e.Error()
+		}
+		return e.render(lang, budget)
+	case *OhNoJoinError:
+		lines := make([]string, len(e.Errors))
+		for i, inner := range e.Errors {
+			lines[i] = localize(inner, lang, budget)
+		}
+		return strings.Join(lines, newline)
+	}
+
+	return localizeCode(err, lang)
+}
+
+// localizeCode prints an error code in the format of the Error() method
+// generated by ohnogen, with the description in the language lang if the code
+// has been translated and lang is not empty.
+func localizeCode(code error, lang string) string {
+	l, ok := code.(interface {
+		ohnoer.OhNoer
+		LocalizedDescription(lang string) string
+	})
+	if lang == "" || !ok {
+		return code.Error()
+	}
+
+	return "[" + l.Code() + "]" + l.Package() + "." + l.String() + ": " + l.LocalizedDescription(lang)
+}
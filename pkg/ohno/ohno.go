@@ -11,6 +11,11 @@
 // capabilities. It also provides structured representation in json and yaml
 // formats
 //
+// The json format is described by [JSONSchema]. Note that a cause which is
+// neither an error of this package nor able to marshal itself is marshaled as
+// its message, where releases before [SchemaVersion] 1 marshaled it as an
+// empty object.
+//
 // [ohnogen]: https://pkg.go.dev/github.com/A-0-5/ohno/cmd/ohnogen
 package ohno // import "github.com/A-0-5/ohno/pkg/ohno"
 
@@ -101,25 +106,65 @@ func ConvertToJoinError(err error) error {
 // not require source information pass sourceInfoType as [sourceinfo.NoSourceInfo]
 // extra, cause, timestamp are optional and will be omitted from printing and
 // marshaling. timestampLayout can be one of the standard timestamp layouts in [time package]. Default is [time.RFC3339Nano].
+// The parameters left at their zero value are set from the [Config] set by
+// [SetConfig].
 //
 // Typically you would not need to use this directly since this would be called
 // internally from the OhNo() method in the code generated by ohnogen.
 //
 // [time package]: https://pkg.go.dev/time#pkg-constants
 func New(ohnoer ohnoer.OhNoer, message string, extra any, cause error, sourceInfoType sourceinfo.SourceInfoType, callDepth int, timeStamp time.Time, timestampLayout string) error {
-	if !timeStamp.IsZero() {
-		if timestampLayout == "" {
-			timestampLayout = time.RFC3339Nano
-		}
-	}
-	return &OhNoError{
+	return GetConfig().complete(&OhNoError{
 		ErrorCode:       ohnoer,
 		Message:         message,
 		Extra:           extra,
 		Cause:           cause,
-		SourceInfo:      sourceinfo.GetSourceInformation(callDepth+1, sourceInfoType),
 		Timestamp:       timeStamp,
 		TimestampLayout: timestampLayout,
+	}, sourceInfoType, callDepth+1)
+}
+
+// NewUncoded generates a new error of [OhNoError] type without an error code,
+// for errors which carry context but do not deserve a code of their own. It
+// is printed starting with its message and marshaled without the fields of
+// the code. The parameters are the same as the ones of [New].
+func NewUncoded(message string, extra any, cause error, sourceInfoType sourceinfo.SourceInfoType, callDepth int, timeStamp time.Time, timestampLayout string) error {
+	return GetConfig().complete(&OhNoError{
+		Message:         message,
+		Extra:           extra,
+		Cause:           cause,
+		Timestamp:       timeStamp,
+		TimestampLayout: timestampLayout,
+	}, sourceInfoType, callDepth+1)
+}
+
+// walk calls visit for err and every error it wraps, depth first in the
+// order [errors.Is] would look at them. The error code of an [OhNoError] is
+// visited right after the error itself, before its cause. The walk stops as
+// soon as visit returns false, walk then returns false as well.
+func walk(err error, visit func(error) bool) bool {
+	if err == nil {
+		return true
+	}
+	if !visit(err) {
+		return false
 	}
 
+	if o, ok := err.(*OhNoError); ok && o != nil && o.ErrorCode != nil {
+		if !walk(o.ErrorCode, visit) {
+			return false
+		}
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(e.Unwrap(), visit)
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if !walk(inner, visit) {
+				return false
+			}
+		}
+	}
+	return true
 }
@@ -14,6 +14,7 @@ import (
 
 	"github.com/A-0-5/ohno/pkg/ohnoer"
 	"github.com/A-0-5/ohno/pkg/sourceinfo"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -21,17 +22,26 @@ const (
 	commaSeparator string = ", "
 	newline        string = "\n"
 	newlineTab     string = "\n-> "
+	hintPrefix     string = "hint: "
 )
 
 // OhNoError is a structure which holds an error interface which satisfies the
-// ohnoer.OhNoer interface.
+// ohnoer.OhNoer interface. An error without a code, see [NewUncoded], only
+// carries its context.
 type OhNoError struct {
-	// An ohnoer.OhNoer interface error field
+	// An ohnoer.OhNoer interface error field, nil for an error without a code
 	ErrorCode error
 	// A custom message for this instance of the error
 	Message string
+	// A [text/template] like "user {{.UserID}} not found" which filled in
+	// with MessageArgs replaces the description of the error code
+	MessageTemplate string
+	// The arguments of MessageTemplate
+	MessageArgs map[string]any
 	// Any additional data to add context to this error
 	Extra any
+	// Fields adding context to this error, in the order they have been added
+	Fields Fields
 	// The error which led to this error being generated
 	Cause error
 	// File, Line & possibly Function name where this error was generated
@@ -40,17 +50,34 @@ type OhNoError struct {
 	Timestamp time.Time
 	// Layout in which the timestamp needs to be printed refer https://pkg.go.dev/time#pkg-constants
 	TimestampLayout string
+
+	// Configuration the error was built with, nil for an error built as a
+	// struct literal
+	config *Config
 }
 
 // This is the Error() method which satisfies the builtin [error] interface
 // This prints the error in the format
 //
-//	timestamp file:line(function): [code]name: description, message, extra
+//	timestamp file:line(function): [code]name: description, message, extra, key=value key=value, hint: hint
 //		cause(same representation as above with one indent)...
 //
+// If the error has a MessageTemplate, it is filled in with the MessageArgs
+// and printed in place of the description. An error without a code starts
+// with its message. A nil *OhNoError prints as "<nil>".
+//
 // [error]: https://pkg.go.dev/builtin#error
 func (o *OhNoError) Error() string {
+	if o == nil {
+		return "<nil>"
+	}
+	return o.render("", allCauses)
+}
 
+// render prints the error as described by Error(). If lang is not empty the
+// descriptions are in that language where they have been translated. budget
+// is the number of levels of causes the errors it is nested in allow.
+func (o *OhNoError) render(lang string, budget int) string {
 	var ob strings.Builder
 	if !o.Timestamp.IsZero() {
 		if o.TimestampLayout == "" {
@@ -65,30 +92,67 @@ func (o *OhNoError) Error() string {
 		ob.WriteString(separator)
 	}
 
-	ob.WriteString(o.ErrorCode.Error())
+	// The parts after the code are separated by commas, an error without a
+	// code starts with the first of them.
+	head := ob.Len()
+	part := func(s string) {
+		if ob.Len() > head {
+			ob.WriteString(commaSeparator)
+		}
+		ob.WriteString(s)
+	}
+
+	rendered := o.renderedMessage(lang)
+	switch code := o.ErrorCode.(type) {
+	case nil:
+		if rendered != "" {
+			part(rendered)
+		}
+	case ohnoer.OhNoer:
+		if rendered != "" {
+			part("[" + code.Code() + "]" + code.Package() + "." + code.String() + ": " + rendered)
+		} else {
+			part(localizeCode(code, lang))
+		}
+	default:
+		part(localizeCode(code, lang))
+		if rendered != "" {
+			part(rendered)
+		}
+	}
 	if o.Message != "" {
-		ob.WriteString(commaSeparator)
-		ob.WriteString(o.Message)
+		part(o.Message)
 	}
 
 	if o.Extra != nil {
-		ob.WriteString(commaSeparator)
-		fmt.Fprintf(&ob, "%+v", o.Extra)
+		part(fmt.Sprintf("%+v", o.Extra))
+	}
+
+	if len(o.Fields) > 0 {
+		part(o.Fields.String())
+	}
+
+	if hint := hintOf(o.ErrorCode); hint != "" {
+		part(hintPrefix + hint)
 	}
 
 	if o.Cause != nil {
 		ob.WriteString(newlineTab)
-		ob.WriteString(o.Cause.Error())
+		if budget = o.causeBudget(budget); budget == 0 {
+			ob.WriteString(omittedCauses)
+		} else {
+			ob.WriteString(localize(o.Cause, lang, nestedBudget(budget)))
+		}
 	}
 
 	return ob.String()
-
 }
 
 // This is a method implementation for usage with [errors.Is] in order to check
-// if any errors in the chain match the current one
+// if any errors in the chain match the current one. An error without a code
+// only matches itself, [errors.Is] still looks at its cause.
 func (o *OhNoError) Is(target error) bool {
-	if o.ErrorCode == nil {
+	if o == nil || o.ErrorCode == nil {
 		return false
 	}
 
@@ -98,6 +162,9 @@ func (o *OhNoError) Is(target error) bool {
 // This is a method implementation for usage with [errors.Unwrap] in order to
 // get the wrapped/nested error
 func (o *OhNoError) Unwrap() error {
+	if o == nil {
+		return nil
+	}
 	return o.Cause
 }
 
@@ -105,34 +172,70 @@ func (o *OhNoError) Unwrap() error {
 //
 // [yaml.Marshaler]: https://pkg.go.dev/gopkg.in/yaml.v3#Marshaler
 func (o *OhNoError) MarshalYAML() (interface{}, error) {
-	if _, ok := o.ErrorCode.(ohnoer.OhNoer); !ok {
+	if o == nil {
+		return nil, nil
+	}
+	if !o.marshalsAsObject() {
 		return o.Error(), nil
 	}
 
-	marshalErr := o.marshalableError()
+	marshalErr := o.marshalableError(allCauses)
 	return marshalErr, nil
 }
 
 // A simple json marshaler implementation for satisfying [encoding/json.Marshaler]
 func (o *OhNoError) MarshalJSON() ([]byte, error) {
-	if _, ok := o.ErrorCode.(ohnoer.OhNoer); !ok {
+	if o == nil {
+		return []byte("null"), nil
+	}
+	if !o.marshalsAsObject() {
 		return json.Marshal(o.Error())
 	}
 
-	marshalErr := o.marshalableError()
+	marshalErr := o.marshalableError(allCauses)
 	return json.Marshal(marshalErr)
 }
 
-func (o *OhNoError) marshalableError() *ohNoMarshalError {
-	marshalErr := new(ohNoMarshalError)
+// marshalsAsObject reports whether the error is marshaled as an object, which
+// is the case when its code has been generated by ohnogen or it has none. An
+// error with any other code is marshaled as its message.
+func (o *OhNoError) marshalsAsObject() bool {
+	if o.ErrorCode == nil {
+		return true
+	}
+	_, ok := o.ErrorCode.(ohnoer.OhNoer)
+	return ok
+}
 
-	ohnoer := o.ErrorCode.(ohnoer.OhNoer)
-	marshalErr.Package = ohnoer.Package()
-	marshalErr.Code = ohnoer.Code()
-	marshalErr.Name = ohnoer.String()
-	marshalErr.Description = ohnoer.Description()
+// marshalableError returns the value marshaled in place of the error, budget
+// is the number of levels of causes the errors it is nested in allow. An
+// error without a code is marshaled without the fields describing the code.
+func (o *OhNoError) marshalableError(budget int) any {
+	marshalErr := new(ohNoMarshalError)
+	marshalErr.SchemaVersion = SchemaVersion
+
+	if ohnoer, ok := o.ErrorCode.(ohnoer.OhNoer); ok {
+		marshalErr.Package = ohnoer.Package()
+		marshalErr.Code = ohnoer.Code()
+		marshalErr.Name = ohnoer.String()
+		marshalErr.Description = ohnoer.Description()
+		marshalErr.Severity = severityOf(ohnoer)
+		if d, ok := ohnoer.(interface{ DocURL() string }); ok {
+			marshalErr.Help = d.DocURL()
+		}
+		marshalErr.Hint = hintOf(ohnoer)
+	}
 	marshalErr.Message = o.Message
+	if o.MessageTemplate != "" {
+		marshalErr.MessageTemplate = o.MessageTemplate
+		marshalErr.MessageArgs = o.MessageArgs
+		marshalErr.Message = o.renderedMessage("")
+		if o.Message != "" {
+			marshalErr.Message += commaSeparator + o.Message
+		}
+	}
 	marshalErr.AdditionalInfo = o.Extra
+	marshalErr.Fields = o.Fields
 
 	if !o.Timestamp.IsZero() {
 		if o.TimestampLayout == "" {
@@ -147,12 +250,48 @@ func (o *OhNoError) marshalableError() *ohNoMarshalError {
 	}
 
 	if o.Cause != nil {
-		marshalErr.CausedBy = o.Cause
+		if budget = o.causeBudget(budget); budget == 0 {
+			marshalErr.CausedBy = omittedCauses
+		} else {
+			marshalErr.CausedBy = marshalableCause(o.Cause, nestedBudget(budget))
+		}
 	}
 
+	if o.ErrorCode == nil {
+		return marshalErr.uncoded()
+	}
 	return marshalErr
 }
 
+// hintOf returns the hint of an error code generated by ohnogen for codes
+// with a hint, or an empty string.
+func hintOf(code error) string {
+	if h, ok := code.(interface{ Hint() string }); ok {
+		return h.Hint()
+	}
+	return ""
+}
+
+// marshalableCause returns the value to be marshaled in place of a nested
+// error, budget is the number of levels of causes allowed below it. Errors of this package and errors which know how
+// to marshal themselves are returned as they are, any other error is replaced
+// by its message since it would otherwise be marshaled as an empty object.
+func marshalableCause(err error, budget int) any {
+	switch e := err.(type) {
+	case *OhNoError:
+		if e != nil && e.marshalsAsObject() {
+			return e.marshalableError(budget)
+		}
+		return err
+	case *OhNoJoinError:
+		return e.marshalableError(budget)
+	case json.Marshaler, yaml.Marshaler:
+		return err
+	}
+
+	return err.Error()
+}
+
 func (o *OhNoError) drillDownAndStackUp() error {
 	errorList := []error{}
 	errorList = o.removeCauseAndAppendRecursive(errorList)
@@ -165,10 +304,14 @@ func (o *OhNoError) removeCauseAndAppendRecursive(errs []error) []error {
 	oNew := &OhNoError{
 		ErrorCode:       o.ErrorCode,
 		Message:         o.Message,
+		MessageTemplate: o.MessageTemplate,
+		MessageArgs:     o.MessageArgs,
 		Extra:           o.Extra,
+		Fields:          o.Fields,
 		SourceInfo:      o.SourceInfo,
 		Timestamp:       o.Timestamp,
 		TimestampLayout: o.TimestampLayout,
+		config:          o.config,
 	}
 
 	errs = append(errs, oNew)
@@ -190,13 +333,49 @@ func (o *OhNoError) removeCauseAndAppendRecursive(errs []error) []error {
 }
 
 type ohNoMarshalError struct {
-	AdditionalInfo any                           `json:"additional_info,omitempty" yaml:"additional_info,omitempty"`
-	CausedBy       error                         `json:"caused_by,omitempty" yaml:"caused_by,omitempty"`
-	SourceInfo     *sourceinfo.SourceInformation `json:"source_information,omitempty" yaml:"source_information,omitempty"`
-	Package        string                        `json:"package" yaml:"package"`
-	Code           string                        `json:"code" yaml:"code"`
-	Name           string                        `json:"name" yaml:"name"`
-	Message        string                        `json:"message,omitempty" yaml:"message,omitempty"`
-	Description    string                        `json:"description" yaml:"description"`
-	TimeStamp      string                        `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	SchemaVersion   string                        `json:"schema_version" yaml:"schema_version"`
+	AdditionalInfo  any                           `json:"additional_info,omitempty" yaml:"additional_info,omitempty"`
+	Fields          Fields                        `json:"fields,omitempty" yaml:"fields,omitempty"`
+	CausedBy        any                           `json:"caused_by,omitempty" yaml:"caused_by,omitempty"`
+	SourceInfo      *sourceinfo.SourceInformation `json:"source_information,omitempty" yaml:"source_information,omitempty"`
+	Package         string                        `json:"package" yaml:"package"`
+	Code            string                        `json:"code" yaml:"code"`
+	Name            string                        `json:"name" yaml:"name"`
+	Message         string                        `json:"message,omitempty" yaml:"message,omitempty"`
+	MessageTemplate string                        `json:"message_template,omitempty" yaml:"message_template,omitempty"`
+	MessageArgs     map[string]any                `json:"message_args,omitempty" yaml:"message_args,omitempty"`
+	Description     string                        `json:"description" yaml:"description"`
+	Severity        Severity                      `json:"severity,omitempty" yaml:"severity,omitempty"`
+	Help            string                        `json:"help,omitempty" yaml:"help,omitempty"`
+	Hint            string                        `json:"hint,omitempty" yaml:"hint,omitempty"`
+	TimeStamp       string                        `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// ohNoMarshalUncodedError is marshaled in place of an error without a code,
+// it has none of the fields describing the code.
+type ohNoMarshalUncodedError struct {
+	SchemaVersion   string                        `json:"schema_version" yaml:"schema_version"`
+	AdditionalInfo  any                           `json:"additional_info,omitempty" yaml:"additional_info,omitempty"`
+	Fields          Fields                        `json:"fields,omitempty" yaml:"fields,omitempty"`
+	CausedBy        any                           `json:"caused_by,omitempty" yaml:"caused_by,omitempty"`
+	SourceInfo      *sourceinfo.SourceInformation `json:"source_information,omitempty" yaml:"source_information,omitempty"`
+	Message         string                        `json:"message,omitempty" yaml:"message,omitempty"`
+	MessageTemplate string                        `json:"message_template,omitempty" yaml:"message_template,omitempty"`
+	MessageArgs     map[string]any                `json:"message_args,omitempty" yaml:"message_args,omitempty"`
+	TimeStamp       string                        `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+}
+
+// uncoded returns the fields of m which an error without a code has.
+func (m *ohNoMarshalError) uncoded() *ohNoMarshalUncodedError {
+	return &ohNoMarshalUncodedError{
+		SchemaVersion:   m.SchemaVersion,
+		AdditionalInfo:  m.AdditionalInfo,
+		Fields:          m.Fields,
+		CausedBy:        m.CausedBy,
+		SourceInfo:      m.SourceInfo,
+		Message:         m.Message,
+		MessageTemplate: m.MessageTemplate,
+		MessageArgs:     m.MessageArgs,
+		TimeStamp:       m.TimeStamp,
+	}
 }
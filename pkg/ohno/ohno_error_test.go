@@ -0,0 +1,67 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOhNoErrorMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		json string
+		yaml string
+	}{
+		{
+			name: "coded",
+			err:  ohno.New(notFound, "", nil, nil, sourceinfo.NoSourceInfo, 0, time.Time{}, ""),
+			json: `{"schema_version":"1","package":"ohno_test","code":"1","name":"NotFound","description":"NotFound description"}`,
+			yaml: "schema_version: \"1\"\npackage: ohno_test\ncode: \"1\"\nname: NotFound\ndescription: NotFound description\n",
+		},
+		{
+			name: "uncoded",
+			err:  ohno.NewUncoded("retrying", nil, nil, sourceinfo.NoSourceInfo, 0, time.Time{}, ""),
+			json: `{"schema_version":"1","message":"retrying"}`,
+			yaml: "schema_version: \"1\"\nmessage: retrying\n",
+		},
+		{
+			name: "uncoded with a coded cause",
+			err:  ohno.NewUncoded("retrying", nil, notFound, sourceinfo.NoSourceInfo, 0, time.Time{}, ""),
+			json: `{"schema_version":"1","caused_by":"NotFound: NotFound description","message":"retrying"}`,
+			yaml: "schema_version: \"1\"\ncaused_by: 'NotFound: NotFound description'\nmessage: retrying\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.json {
+				t.Errorf("json %s, want %s", b, tt.json)
+			}
+			if err := ohno.Validate(b); err != nil {
+				t.Errorf("json does not match the schema: %s", err)
+			}
+
+			b, err = yaml.Marshal(tt.err)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.yaml {
+				t.Errorf("yaml %q, want %q", b, tt.yaml)
+			}
+		})
+	}
+}
@@ -5,6 +5,8 @@
 
 package ohno
 
+import "encoding/json"
+
 // This is structural representation of multiple errors in the same level. It
 // is implemented as an array of errors
 type OhNoJoinError struct {
@@ -36,3 +38,33 @@ func (oj *OhNoJoinError) Error() string {
 func (oj *OhNoJoinError) Unwrap() []error {
 	return oj.Errors
 }
+
+// A simple yaml marshaler implementation for satisfying [yaml.Marshaler]
+//
+// [yaml.Marshaler]: https://pkg.go.dev/gopkg.in/yaml.v3#Marshaler
+func (oj *OhNoJoinError) MarshalYAML() (interface{}, error) {
+	return oj.marshalableError(allCauses), nil
+}
+
+// A simple json marshaler implementation for satisfying [encoding/json.Marshaler]
+func (oj *OhNoJoinError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(oj.marshalableError(allCauses))
+}
+
+// marshalableError returns the value marshaled in place of the error, budget
+// is the number of levels of causes the errors it is nested in allow.
+func (oj *OhNoJoinError) marshalableError(budget int) *ohNoMarshalJoinError {
+	marshalErr := &ohNoMarshalJoinError{
+		Errors: make([]any, len(oj.Errors)),
+	}
+
+	for i, err := range oj.Errors {
+		marshalErr.Errors[i] = marshalableCause(err, budget)
+	}
+
+	return marshalErr
+}
+
+type ohNoMarshalJoinError struct {
+	Errors []any `json:"errors" yaml:"errors"`
+}
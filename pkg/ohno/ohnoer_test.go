@@ -0,0 +1,40 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import "strconv"
+
+// code is an error code like the ones generated by ohnogen.
+type code int
+
+const (
+	notFound code = iota + 1
+	internal
+)
+
+func (c code) String() string {
+	switch c {
+	case notFound:
+		return "NotFound"
+	case internal:
+		return "Internal"
+	}
+	return "code(" + strconv.Itoa(int(c)) + ")"
+}
+
+func (c code) Description() string { return c.String() + " description" }
+func (c code) Error() string       { return c.String() + ": " + c.Description() }
+func (c code) Package() string     { return "ohno_test" }
+func (c code) Code() string        { return strconv.Itoa(int(c)) }
+
+// uncomparableCode is an error code which panics when compared with ==.
+type uncomparableCode []string
+
+func (c uncomparableCode) String() string      { return c[0] }
+func (c uncomparableCode) Description() string { return c[0] + " description" }
+func (c uncomparableCode) Error() string       { return c.String() + ": " + c.Description() }
+func (c uncomparableCode) Package() string     { return "ohno_test" }
+func (c uncomparableCode) Code() string        { return c[0] }
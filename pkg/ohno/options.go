@@ -0,0 +1,105 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"context"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// Option sets a part of the error built by [NewWithOptions] or by the With
+// method generated by ohnogen. Anything not set by an option is left empty or
+// set from the [Config].
+type Option func(*options)
+
+// options collects what the options set before the error is built.
+type options struct {
+	ctx            context.Context
+	err            OhNoError
+	sourceInfoType sourceinfo.SourceInfoType
+	sourceSet      bool // Whether WithSource was given
+}
+
+// WithMessage sets the custom message of the error.
+func WithMessage(message string) Option {
+	return func(o *options) {
+		o.err.Message = message
+	}
+}
+
+// WithCause sets the error which led to the error.
+func WithCause(cause error) Option {
+	return func(o *options) {
+		o.err.Cause = cause
+	}
+}
+
+// WithExtra sets the additional data adding context to the error.
+func WithExtra(extra any) Option {
+	return func(o *options) {
+		o.err.Extra = extra
+	}
+}
+
+// WithFields sets the fields of the error, see [OhNoError.AddFields].
+func WithFields(kvs ...KeyValue) Option {
+	return func(o *options) {
+		o.err.AddFields(kvs...)
+	}
+}
+
+// WithSource captures the source information of the place where the error is
+// built in the format given by sourceInfoType instead of the one of the
+// [Config], [sourceinfo.NoSourceInfo] captures none.
+func WithSource(sourceInfoType sourceinfo.SourceInfoType) Option {
+	return func(o *options) {
+		o.sourceInfoType = sourceInfoType
+		o.sourceSet = true
+	}
+}
+
+// WithTimestamp sets the time at which the error occurred and the layout in
+// which it is printed, [time.RFC3339Nano] if layout is empty.
+func WithTimestamp(timestamp time.Time, layout string) Option {
+	return func(o *options) {
+		o.err.Timestamp = timestamp
+		o.err.TimestampLayout = layout
+	}
+}
+
+// WithContext builds the error with the [Config] carried by ctx, see
+// [WithConfig].
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// NewWithOptions generates a new error of [OhNoError] type with the error
+// code ohnoer and the parts set by opts. callDepth is the same as the one of
+// [New], it is only used when source information is captured.
+//
+// Typically you would not need to use this directly since this would be called
+// internally from the With() method in the code generated by ohnogen.
+func NewWithOptions(ohnoer ohnoer.OhNoer, callDepth int, opts ...Option) error {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	o.err.ErrorCode = ohnoer
+	c := ConfigFrom(o.ctx)
+	sourceInfoType := o.sourceInfoType
+	if !o.sourceSet {
+		sourceInfoType = c.SourceInfoType
+	}
+	return c.completeWithSource(&o.err, sourceInfoType, callDepth+1)
+}
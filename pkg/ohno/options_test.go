@@ -0,0 +1,77 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+func TestSourceOverride(t *testing.T) {
+	ctx := ohno.WithConfig(context.Background(), ohno.Config{SourceInfoType: sourceinfo.ShortFileAndLine})
+
+	tests := []struct {
+		name   string
+		build  func() error
+		source bool // whether source information is captured
+		full   bool // whether it holds the full path of the file
+	}{
+		{
+			name: "options from the config",
+			build: func() error {
+				return ohno.NewWithOptions(notFound, sourceinfo.DefaultCallDepth, ohno.WithContext(ctx))
+			},
+			source: true,
+		},
+		{
+			name: "options turned off",
+			build: func() error {
+				return ohno.NewWithOptions(notFound, sourceinfo.DefaultCallDepth, ohno.WithContext(ctx), ohno.WithSource(sourceinfo.NoSourceInfo))
+			},
+		},
+		{
+			name: "options in another format",
+			build: func() error {
+				return ohno.NewWithOptions(notFound, sourceinfo.DefaultCallDepth, ohno.WithContext(ctx), ohno.WithSource(sourceinfo.FullFileAndLine))
+			},
+			source: true,
+			full:   true,
+		},
+		{
+			name: "builder from the config",
+			build: func() error {
+				return ohno.Build(notFound).Context(ctx).Err()
+			},
+			source: true,
+		},
+		{
+			name: "builder turned off",
+			build: func() error {
+				return ohno.Build(notFound).Context(ctx).Source(sourceinfo.NoSourceInfo).Err()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := tt.build().(*ohno.OhNoError)
+			switch {
+			case !tt.source && o.SourceInfo != nil:
+				t.Errorf("source %v, want none", o.SourceInfo)
+			case tt.source && o.SourceInfo == nil:
+				t.Error("no source, want one")
+			case tt.source && filepath.Base(o.SourceInfo.File) != "options_test.go":
+				t.Errorf("source %v, want options_test.go", o.SourceInfo)
+			case tt.source && filepath.IsAbs(o.SourceInfo.File) != tt.full:
+				t.Errorf("source %v, want the full path %t", o.SourceInfo, tt.full)
+			}
+		})
+	}
+}
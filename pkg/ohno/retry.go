@@ -0,0 +1,145 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// IsRetryable reports whether the operation which failed with err may succeed
+// if it is tried again. The errors in the tree of err are looked at depth
+// first, in the order [errors.Is] would, and the first one which classifies
+// itself decides. An error classifies itself by having one of the methods
+//
+//	Retryable() bool // generated by ohnogen for codes annotated //ohno:retryable
+//	Temporary() bool
+//	Timeout() bool
+//
+// checked in this order, so that errors of other packages like net.Error
+// are honored as well. If no error classifies itself err is not retryable.
+func IsRetryable(err error) bool {
+	retryable := false
+	walk(err, func(e error) bool {
+		switch c := e.(type) {
+		case interface{ Retryable() bool }:
+			retryable = c.Retryable()
+		case interface{ Temporary() bool }:
+			retryable = c.Temporary()
+		case interface{ Timeout() bool }:
+			retryable = c.Timeout()
+		default:
+			return true
+		}
+		return false
+	})
+	return retryable
+}
+
+// RetryPolicy configures [Retry]. The zero value of each field means its
+// default.
+type RetryPolicy struct {
+	// Number of times the function is called at most, 3 by default
+	MaxAttempts int
+	// Time waited after the first failure, 100ms by default
+	InitialBackoff time.Duration
+	// Upper bound of the time waited between attempts, 10s by default
+	MaxBackoff time.Duration
+	// Factor by which the time waited grows after every failure, 2 by default
+	Multiplier float64
+	// Fraction between 0 and 1 of the time waited which is randomized, so
+	// that clients failing together do not retry together. No jitter by
+	// default
+	Jitter float64
+}
+
+// Defaults of the fields of a [RetryPolicy].
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+	defaultMultiplier     = 2
+)
+
+// withDefaults returns the policy with the unset fields set to their
+// defaults.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = defaultMaxBackoff
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = defaultMultiplier
+	}
+	if p.Jitter < 0 {
+		p.Jitter = 0
+	} else if p.Jitter > 1 {
+		p.Jitter = 1
+	}
+	return p
+}
+
+// jitterRand returns a random number in [0, 1) to randomize the backoff, it
+// is replaced by the tests.
+var jitterRand = rand.Float64
+
+// backoff returns the time to wait after the given number of failed attempts.
+func (p RetryPolicy) backoff(failures int) time.Duration {
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < failures && delay < float64(p.MaxBackoff); i++ {
+		delay *= p.Multiplier
+	}
+	if delay > float64(p.MaxBackoff) {
+		delay = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * jitterRand()
+	}
+	return time.Duration(delay)
+}
+
+// wait blocks for d or until ctx is done, in which case it returns the error
+// of the context. It is replaced by the tests.
+var wait = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Retry calls fn until it succeeds, fails with an error which is not
+// retryable according to [IsRetryable] or policy.MaxAttempts calls have been
+// made, waiting with an exponential backoff between the calls. It returns nil
+// on success, otherwise the error of the last call. If ctx is done while
+// waiting, the error of the last call is returned joined with the error of
+// the context.
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) error {
+	policy = policy.withDefaults()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !IsRetryable(err) {
+			return err
+		}
+
+		if ctxErr := wait(ctx, policy.backoff(attempt)); ctxErr != nil {
+			return Join(err, ctxErr)
+		}
+	}
+}
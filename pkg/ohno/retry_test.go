@@ -0,0 +1,178 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+)
+
+// retryable is an error which classifies itself as retryable or not.
+type retryable bool
+
+func (r retryable) Error() string   { return "retryable error" }
+func (r retryable) Retryable() bool { return bool(r) }
+
+func TestBackoff(t *testing.T) {
+	policy := ohno.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+	want := []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 900 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := ohno.Backoff(policy, i+1); got != w {
+			t.Errorf("backoff after %d failures %s, want %s", i+1, got, w)
+		}
+	}
+
+	if got, want := ohno.Backoff(ohno.RetryPolicy{}, 2), 200*time.Millisecond; got != want {
+		t.Errorf("default backoff after 2 failures %s, want %s", got, want)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := ohno.RetryPolicy{InitialBackoff: time.Second, Jitter: 0.25}
+	tests := []struct {
+		random float64
+		want   time.Duration
+	}{
+		{random: 0, want: time.Second},
+		{random: 0.5, want: 875 * time.Millisecond},
+		{random: 1, want: 750 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		restore := ohno.SetRetryHooks(nil, func() float64 { return tt.random })
+		got := ohno.Backoff(policy, 1)
+		restore()
+		if got != tt.want {
+			t.Errorf("backoff with the random number %v is %s, want %s", tt.random, got, tt.want)
+		}
+	}
+
+	// Without a fixed random number the backoff stays within the jitter.
+	for i := 0; i < 1000; i++ {
+		if got := ohno.Backoff(policy, 1); got < 750*time.Millisecond || got > time.Second {
+			t.Fatalf("backoff %s out of [750ms, 1s]", got)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  ohno.RetryPolicy
+		results []error // returned by the calls in order, nil once exhausted
+		calls   int
+		waits   []time.Duration
+		wantErr error
+	}{
+		{
+			name:    "success",
+			results: []error{nil},
+			calls:   1,
+		},
+		{
+			name:    "success after retries",
+			policy:  ohno.RetryPolicy{MaxAttempts: 5},
+			results: []error{retryable(true), retryable(true)},
+			calls:   3,
+			waits:   []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:    "attempts exhausted",
+			policy:  ohno.RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second},
+			results: []error{retryable(true), retryable(true), retryable(true), retryable(true)},
+			calls:   4,
+			waits:   []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+			wantErr: retryable(true),
+		},
+		{
+			name:    "not retryable",
+			results: []error{retryable(true), retryable(false)},
+			calls:   2,
+			waits:   []time.Duration{100 * time.Millisecond},
+			wantErr: retryable(false),
+		},
+		{
+			name:    "not classified",
+			results: []error{notFound},
+			calls:   1,
+			wantErr: notFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var waits []time.Duration
+			defer ohno.SetRetryHooks(func(ctx context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}, nil)()
+
+			calls := 0
+			err := ohno.Retry(context.Background(), tt.policy, func(context.Context) error {
+				calls++
+				if calls > len(tt.results) {
+					return nil
+				}
+				return tt.results[calls-1]
+			})
+			if err != tt.wantErr {
+				t.Errorf("Retry() = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.calls {
+				t.Errorf("%d calls, want %d", calls, tt.calls)
+			}
+			if !reflect.DeepEqual(waits, tt.waits) {
+				t.Errorf("waited %v, want %v", waits, tt.waits)
+			}
+		})
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	t.Run("while waiting", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		calls := 0
+		start := time.Now()
+		err := ohno.Retry(ctx, ohno.RetryPolicy{InitialBackoff: time.Hour}, func(context.Context) error {
+			calls++
+			cancel()
+			return retryable(true)
+		})
+		if time.Since(start) > time.Minute {
+			t.Errorf("Retry() waited for the backoff after the context was canceled")
+		}
+		if calls != 1 {
+			t.Errorf("%d calls, want 1", calls)
+		}
+		if !errors.Is(err, retryable(true)) || !errors.Is(err, context.Canceled) {
+			t.Errorf("Retry() = %v, want the error of the call joined with %v", err, context.Canceled)
+		}
+	})
+
+	t.Run("before the first call", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := ohno.Retry(ctx, ohno.RetryPolicy{}, func(context.Context) error {
+			calls++
+			return nil
+		})
+		if calls != 0 {
+			t.Errorf("%d calls, want 0", calls)
+		}
+		if err != context.Canceled {
+			t.Errorf("Retry() = %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,395 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SchemaVersion is the version of the format in which errors are marshaled.
+// It is written to the schema_version field of every marshaled [OhNoError]
+// and is bumped whenever the format changes in an incompatible way.
+const SchemaVersion = "1"
+
+//go:embed schema/ohno_error.v1.schema.json
+var jsonSchema []byte
+
+var (
+	parseSchemaOnce sync.Once
+	parsedSchema    map[string]any
+	parseSchemaErr  error
+)
+
+// JSONSchema returns the [JSON Schema] describing the json produced when
+// marshaling an [OhNoError] or an [OhNoJoinError] in the current
+// [SchemaVersion]. The same document is shipped in the schema directory of
+// this package.
+//
+// [JSON Schema]: https://json-schema.org/draft/2020-12/schema
+func JSONSchema() []byte {
+	return bytes.Clone(jsonSchema)
+}
+
+// Validate checks whether payload is an error marshaled to json in the format
+// described by [JSONSchema]. It returns nil if it is, otherwise the returned
+// error lists every mismatch along with its location in the payload.
+//
+// Only the parts of JSON Schema used by the schema of this package are
+// understood, so this is not a general purpose validator. A schema using any
+// other keyword is rejected rather than partly checked.
+func Validate(payload []byte) error {
+	parseSchemaOnce.Do(func() {
+		parsedSchema, parseSchemaErr = parseSchema(jsonSchema)
+	})
+	if parseSchemaErr != nil {
+		return fmt.Errorf("parsing the ohno error schema: %w", parseSchemaErr)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("payload is not valid json: %w", err)
+	}
+
+	v := &validator{root: parsedSchema}
+	v.validate(parsedSchema, doc, "$")
+	if len(v.problems) > 0 {
+		return fmt.Errorf("payload does not match the ohno error schema version %s:\n%s", SchemaVersion, strings.Join(v.problems, "\n"))
+	}
+
+	return nil
+}
+
+// schemaKeywords are the keywords understood by the validator. Annotations
+// are accepted as well since they do not constrain the document, format being
+// an annotation too in JSON Schema 2020-12 unless a vocabulary asserts it.
+var schemaKeywords = map[string]bool{
+	"$ref":                 true,
+	"type":                 true,
+	"const":                true,
+	"enum":                 true,
+	"anyOf":                true,
+	"oneOf":                true,
+	"properties":           true,
+	"required":             true,
+	"dependentRequired":    true,
+	"additionalProperties": true,
+	"items":                true,
+	"minItems":             true,
+
+	"$schema":     true,
+	"$id":         true,
+	"$defs":       true,
+	"title":       true,
+	"description": true,
+	"format":      true,
+}
+
+// parseSchema decodes a schema and checks that it only uses the keywords the
+// validator understands, so that a constraint is never silently ignored.
+func parseSchema(data []byte) (map[string]any, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	if err := checkKeywords(schema, "#"); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// checkKeywords checks the keywords of schema, found at path, and of the
+// schemas within it.
+func checkKeywords(schema map[string]any, path string) error {
+	keys := make([]string, 0, len(schema))
+	for k := range schema {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if !schemaKeywords[k] {
+			return fmt.Errorf("%s: unsupported schema keyword %q", path, k)
+		}
+
+		var subschemas []any
+		switch k {
+		case "$defs", "properties":
+			m, _ := schema[k].(map[string]any)
+			names := make([]string, 0, len(m))
+			for name := range m {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				sub, ok := m[name].(map[string]any)
+				if !ok {
+					return fmt.Errorf("%s/%s/%s: schema is not an object", path, k, name)
+				}
+				if err := checkKeywords(sub, path+"/"+k+"/"+name); err != nil {
+					return err
+				}
+			}
+			continue
+		case "anyOf", "oneOf":
+			subschemas, _ = schema[k].([]any)
+		case "items", "additionalProperties":
+			if _, ok := schema[k].(bool); ok {
+				continue
+			}
+			subschemas = []any{schema[k]}
+		}
+
+		for i, s := range subschemas {
+			subPath := path + "/" + k
+			if k == "anyOf" || k == "oneOf" {
+				subPath += "/" + strconv.Itoa(i)
+			}
+			sub, ok := s.(map[string]any)
+			if !ok {
+				return fmt.Errorf("%s: schema is not an object", subPath)
+			}
+			if err := checkKeywords(sub, subPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validator checks a decoded json document against a decoded schema and
+// collects the mismatches.
+type validator struct {
+	root     map[string]any
+	problems []string
+	// Path of the document checked against a schema of an anyOf
+	at string
+	// Set if the document at, rather than something within it, is of the
+	// wrong type.
+	wrongType bool
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// validate checks doc, found at path, against schema.
+func (v *validator) validate(schema map[string]any, doc any, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		target := v.resolve(ref)
+		if target == nil {
+			v.fail(path, "unresolvable schema reference %s", ref)
+			return
+		}
+		v.validate(target, doc, path)
+	}
+
+	if t, ok := schema["type"]; ok && !matchesType(t, doc) {
+		v.fail(path, "expected %v but found %s", t, jsonType(doc))
+		v.wrongType = v.wrongType || path == v.at
+		return
+	}
+
+	if c, ok := schema["const"]; ok && !equalJSON(c, doc) {
+		v.fail(path, "expected %v but found %v", c, doc)
+	}
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, e := range enum {
+			if equalJSON(e, doc) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.fail(path, "%v is not one of %v", doc, enum)
+		}
+	}
+
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		v.validateAnyOf(anyOf, doc, path, false)
+	}
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		v.validateAnyOf(oneOf, doc, path, true)
+	}
+
+	if obj, ok := doc.(map[string]any); ok {
+		v.validateObject(schema, obj, path)
+	}
+
+	if arr, ok := doc.([]any); ok {
+		if minItems, ok := schema["minItems"].(float64); ok && float64(len(arr)) < minItems {
+			v.fail(path, "expected at least %v items but found %d", minItems, len(arr))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range arr {
+				v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+}
+
+// validateAnyOf checks that doc matches at least one of the schemas, or
+// exactly one if one is set. If it matches none, the mismatches of the
+// closest schema are reported, which is the one with the fewest mismatches
+// among those of the same type as doc.
+func (v *validator) validateAnyOf(schemas []any, doc any, path string, one bool) {
+	var closest []string
+	matches := 0
+	for _, s := range schemas {
+		sub := &validator{root: v.root, at: path}
+		sub.validate(s.(map[string]any), doc, path)
+		if len(sub.problems) == 0 {
+			matches++
+			continue
+		}
+		if sub.wrongType {
+			continue
+		}
+		if closest == nil || len(sub.problems) < len(closest) {
+			closest = sub.problems
+		}
+	}
+
+	switch {
+	case matches > 1 && one:
+		v.fail(path, "matches %d of the allowed schemas instead of one", matches)
+	case matches > 0:
+	case closest == nil:
+		v.fail(path, "%s is not allowed here", jsonType(doc))
+	default:
+		v.problems = append(v.problems, closest...)
+	}
+}
+
+// validateObject checks the properties of obj against schema.
+func (v *validator) validateObject(schema map[string]any, obj map[string]any, path string) {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			if _, ok := obj[r.(string)]; !ok {
+				v.fail(path, "missing required property %q", r)
+			}
+		}
+	}
+	if dependent, ok := schema["dependentRequired"].(map[string]any); ok {
+		keys := make([]string, 0, len(dependent))
+		for k := range dependent {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, ok := obj[k]; !ok {
+				continue
+			}
+			for _, r := range dependent[k].([]any) {
+				if _, ok := obj[r.(string)]; !ok {
+					v.fail(path, "missing property %q required by %q", r, k)
+				}
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if p, ok := properties[k].(map[string]any); ok {
+			v.validate(p, obj[k], path+"."+k)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unexpected property %q", k)
+			}
+		case map[string]any:
+			v.validate(additional, obj[k], path+"."+k)
+		}
+	}
+}
+
+// resolve returns the schema referred to by a local reference like
+// #/$defs/name.
+func (v *validator) resolve(ref string) map[string]any {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+
+	var current any = v.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+
+	schema, _ := current.(map[string]any)
+	return schema
+}
+
+// matchesType reports whether doc is of the json type t, which is either the
+// name of a type or a list of names.
+func matchesType(t any, doc any) bool {
+	switch t := t.(type) {
+	case string:
+		actual := jsonType(doc)
+		return actual == t || (t == "number" && actual == "integer")
+	case []any:
+		for _, name := range t {
+			if matchesType(name, doc) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonType returns the json type name of a decoded value.
+func jsonType(doc any) string {
+	switch d := doc.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := d.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", doc)
+}
+
+// equalJSON reports whether two decoded json values are the same.
+func equalJSON(a, b any) bool {
+	x, errX := json.Marshal(a)
+	y, errY := json.Marshal(b)
+	return errX == nil && errY == nil && bytes.Equal(x, y)
+}
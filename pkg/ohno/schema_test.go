@@ -0,0 +1,165 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		wantErr string // substring of the error, empty if the payload is valid
+	}{
+		{
+			name:    "error",
+			payload: `{"schema_version": "1", "package": "p", "code": "0x1", "name": "X", "description": "d", "caused_by": "plain"}`,
+		},
+		{
+			name:    "error without a code",
+			payload: `{"schema_version": "1", "message": "m"}`,
+		},
+		{
+			name:    "join error",
+			payload: `{"errors": [{"schema_version": "1"}, "plain", {"errors": ["nested"]}]}`,
+		},
+		{
+			name:    "cause left out",
+			payload: `{"schema_version": "1", "caused_by": "..."}`,
+		},
+		{
+			name:    "invalid json",
+			payload: `{"schema_version":`,
+			wantErr: "payload is not valid json",
+		},
+		{
+			name:    "string",
+			payload: `"anything"`,
+			wantErr: "$: string is not allowed here",
+		},
+		{
+			name:    "number",
+			payload: `42`,
+			wantErr: "$: integer is not allowed here",
+		},
+		{
+			name:    "empty object",
+			payload: `{}`,
+			wantErr: `$: missing required property "schema_version"`,
+		},
+		{
+			name:    "empty join error",
+			payload: `{"errors": []}`,
+			wantErr: "$.errors: expected at least 1 items but found 0",
+		},
+		{
+			name:    "wrong schema version",
+			payload: `{"schema_version": "2"}`,
+			wantErr: "$.schema_version: expected 1 but found 2",
+		},
+		{
+			name:    "wrong type of a property",
+			payload: `{"schema_version": "1", "package": "p", "code": 102, "name": "X", "description": "d"}`,
+			wantErr: "$.code: expected string but found integer",
+		},
+		{
+			name:    "code without its name",
+			payload: `{"schema_version": "1", "package": "p", "code": "0x1", "description": "d"}`,
+			wantErr: `$: missing property "name" required by "code"`,
+		},
+		{
+			name:    "unknown property",
+			payload: `{"schema_version": "1", "colour": "red"}`,
+			wantErr: `$: unexpected property "colour"`,
+		},
+		{
+			name:    "unknown severity",
+			payload: `{"schema_version": "1", "severity": "fatal"}`,
+			wantErr: "$.severity: fatal is not one of",
+		},
+		{
+			name:    "invalid cause",
+			payload: `{"schema_version": "1", "caused_by": 1}`,
+			wantErr: "$.caused_by: integer is not allowed here",
+		},
+		{
+			name:    "invalid error in a join error",
+			payload: `{"errors": [{"schema_version": "1", "line": 3}]}`,
+			wantErr: `$.errors[0]: unexpected property "line"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ohno.Validate([]byte(tt.payload))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %s", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("no error, want one containing %q", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("error %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string // substring of the error, empty if the schema is accepted
+	}{
+		{
+			name:   "shipped schema",
+			schema: string(ohno.JSONSchema()),
+		},
+		{
+			name:   "annotations",
+			schema: `{"$schema": "s", "title": "t", "description": "d", "type": "string", "format": "uri"}`,
+		},
+		{
+			name:    "unknown keyword",
+			schema:  `{"type": "string", "pattern": "^a"}`,
+			wantErr: `#: unsupported schema keyword "pattern"`,
+		},
+		{
+			name:    "unknown keyword in a definition",
+			schema:  `{"$defs": {"a": {"maxLength": 3}}}`,
+			wantErr: `#/$defs/a: unsupported schema keyword "maxLength"`,
+		},
+		{
+			name:    "unknown keyword in a property",
+			schema:  `{"properties": {"a": {"items": {"uniqueItems": true}}}}`,
+			wantErr: `#/properties/a/items: unsupported schema keyword "uniqueItems"`,
+		},
+		{
+			name:    "unknown keyword in an alternative",
+			schema:  `{"anyOf": [{"type": "string"}, {"minimum": 1}]}`,
+			wantErr: `#/anyOf/1: unsupported schema keyword "minimum"`,
+		},
+		{
+			name:   "boolean additional properties",
+			schema: `{"additionalProperties": false}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ohno.ParseSchema([]byte(tt.schema))
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ParseSchema() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ParseSchema() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
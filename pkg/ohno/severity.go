@@ -0,0 +1,97 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity tells how bad an error is, for example to decide whether it should
+// page someone. Error codes get a severity from the //ohno:severity=level
+// annotation handled by ohnogen, which generates a Severity() method
+// returning it.
+type Severity int
+
+// The severities in increasing order. The zero value means the severity is not
+// known.
+const (
+	SeverityUnspecified Severity = iota // No severity was given
+	SeverityDebug                       // Only of interest while debugging
+	SeverityInfo                        // Expected during normal operation
+	SeverityWarning                     // Worth looking into but not urgent
+	SeverityError                       // A failure which needs attention
+	SeverityCritical                    // A failure which needs attention right away
+)
+
+var severityNames = [...]string{
+	SeverityUnspecified: "unspecified",
+	SeverityDebug:       "debug",
+	SeverityInfo:        "info",
+	SeverityWarning:     "warning",
+	SeverityError:       "error",
+	SeverityCritical:    "critical",
+}
+
+// Returns the lower case name of the severity like "warning"
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity returns the severity with the given name, ignoring case.
+func ParseSeverity(name string) (Severity, error) {
+	for s, n := range severityNames {
+		if strings.EqualFold(n, name) {
+			return Severity(s), nil
+		}
+	}
+	return SeverityUnspecified, fmt.Errorf("unknown severity %q", name)
+}
+
+// MarshalText implements [encoding.TextMarshaler] so that the severity is
+// marshaled by its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	if s < 0 || int(s) >= len(severityNames) {
+		return nil, fmt.Errorf("invalid severity %d", int(s))
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements [encoding.TextUnmarshaler], accepting the names
+// returned by String.
+func (s *Severity) UnmarshalText(text []byte) error {
+	parsed, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// severityOf returns the severity of a single error, without looking at the
+// errors it wraps.
+func severityOf(err error) Severity {
+	if s, ok := err.(interface{ Severity() Severity }); ok {
+		return s.Severity()
+	}
+	return SeverityUnspecified
+}
+
+// MaxSeverity returns the highest severity among err, its causes and the
+// errors joined in it, or [SeverityUnspecified] if none of them has one.
+func MaxSeverity(err error) Severity {
+	highest := SeverityUnspecified
+	walk(err, func(e error) bool {
+		if s := severityOf(e); s > highest {
+			highest = s
+		}
+		return true
+	})
+	return highest
+}
@@ -0,0 +1,106 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// NewFromTemplate generates a new error of [OhNoError] type whose message is
+// the [text/template] messageTemplate filled in with args, like
+//
+//	user {{.UserID}} not found in {{.Region}}
+//
+// with args {"UserID": "42", "Region": "eu"}. The template and the arguments
+// are kept apart so that errors with the same template can be grouped, the
+// message can be localized and the arguments are marshaled as structured
+// fields. The other parameters are the same as the ones of [New].
+//
+// Typically you would not need to use this directly since this would be called
+// internally from the constructors generated by ohnogen for error codes whose
+// description has placeholders.
+func NewFromTemplate(ohnoer ohnoer.OhNoer, messageTemplate string, args map[string]any, cause error, sourceInfoType sourceinfo.SourceInfoType, callDepth int, timeStamp time.Time, timestampLayout string) error {
+	return GetConfig().complete(&OhNoError{
+		ErrorCode:       ohnoer,
+		MessageTemplate: messageTemplate,
+		MessageArgs:     args,
+		Cause:           cause,
+		Timestamp:       timeStamp,
+		TimestampLayout: timestampLayout,
+	}, sourceInfoType, callDepth+1)
+}
+
+// maxParsedTemplates bounds the number of cached templates. The templates of
+// the constructors generated by ohnogen are constants and all fit, templates
+// built at run time and passed to [NewFromTemplate] only make the cache be
+// dropped and filled again.
+const maxParsedTemplates = 256
+
+var (
+	parsedTemplatesMu sync.Mutex
+	// parsedTemplates caches the message templates by their text.
+	parsedTemplates = make(map[string]*template.Template)
+)
+
+// parseTemplate returns the parsed template text, from the cache if it has
+// been parsed before.
+func parseTemplate(text string) (*template.Template, error) {
+	parsedTemplatesMu.Lock()
+	t, ok := parsedTemplates[text]
+	parsedTemplatesMu.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	t, err := template.New("message").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedTemplatesMu.Lock()
+	if len(parsedTemplates) >= maxParsedTemplates {
+		parsedTemplates = make(map[string]*template.Template)
+	}
+	parsedTemplates[text] = t
+	parsedTemplatesMu.Unlock()
+	return t, nil
+}
+
+// renderTemplate fills in the template text with args. If the template is
+// invalid or refers to a missing argument the text is returned as it is.
+func renderTemplate(text string, args map[string]any) string {
+	t, err := parseTemplate(text)
+	if err != nil {
+		return text
+	}
+
+	var b strings.Builder
+	if err := t.Execute(&b, args); err != nil {
+		return text
+	}
+	return b.String()
+}
+
+// renderedMessage returns the message template filled in with the
+// arguments, in the language lang if the error code has a description in
+// that language, or an empty string if the error has no message template.
+func (o *OhNoError) renderedMessage(lang string) string {
+	if o.MessageTemplate == "" {
+		return ""
+	}
+
+	text := o.MessageTemplate
+	if l, ok := o.ErrorCode.(interface{ LocalizedDescription(string) string }); ok && lang != "" {
+		text = l.LocalizedDescription(lang)
+	}
+	return renderTemplate(text, o.MessageArgs)
+}
@@ -0,0 +1,76 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+func TestNewFromTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		args     map[string]any
+		want     string
+	}{
+		{
+			name:     "filled in",
+			template: "user {{.UserID}} not found in {{.Region}}",
+			args:     map[string]any{"UserID": 42, "Region": "eu"},
+			want:     "[1]ohno_test.NotFound: user 42 not found in eu",
+		},
+		{
+			name:     "missing argument",
+			template: "user {{.UserID}} not found in {{.Region}}",
+			args:     map[string]any{"UserID": 42},
+			want:     "[1]ohno_test.NotFound: user {{.UserID}} not found in {{.Region}}",
+		},
+		{
+			name:     "invalid template",
+			template: "user {{.UserID not found",
+			args:     map[string]any{"UserID": 42},
+			want:     "[1]ohno_test.NotFound: user {{.UserID not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ohno.NewFromTemplate(notFound, tt.template, tt.args, nil, sourceinfo.NoSourceInfo, 0, time.Time{}, "")
+			if got := err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+
+			var o *ohno.OhNoError
+			if !errors.As(err, &o) {
+				t.Fatalf("%T is not an OhNoError", err)
+			}
+			if o.MessageTemplate != tt.template || !reflect.DeepEqual(o.MessageArgs, tt.args) {
+				t.Errorf("template %q with %v, want %q with %v", o.MessageTemplate, o.MessageArgs, tt.template, tt.args)
+			}
+		})
+	}
+}
+
+func TestParsedTemplatesBound(t *testing.T) {
+	for i := 0; i < 2*ohno.MaxParsedTemplates+1; i++ {
+		text := "attempt " + strconv.Itoa(i) + " of {{.Total}}"
+		err := ohno.NewFromTemplate(notFound, text, map[string]any{"Total": i}, nil, sourceinfo.NoSourceInfo, 0, time.Time{}, "")
+		want := "[1]ohno_test.NotFound: attempt " + strconv.Itoa(i) + " of " + strconv.Itoa(i)
+		if got := err.Error(); got != want {
+			t.Fatalf("Error() = %q, want %q", got, want)
+		}
+		if n := ohno.ParsedTemplates(); n > ohno.MaxParsedTemplates {
+			t.Fatalf("%d templates cached, want at most %d", n, ohno.MaxParsedTemplates)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+// Wrap attaches the error code ohnoer to err, like one of another package,
+// by returning an error of [OhNoError] type with the message and err as its
+// cause. It returns nil if err is nil. The source information, the timestamp
+// and its layout are the ones of the [Config] set by [SetConfig], the source
+// information is the one of the line calling Wrap.
+//
+// If err is already an [OhNoError] with the same error code, a copy of it is
+// returned instead with message put in front of its own. The copy keeps the
+// timestamp of err, and its source information too when the [Config] captures
+// none. If err is the error code itself it is not the cause. The error code is
+// attached even when it is found deeper in the chain of err, so that the
+// outermost error always tells it.
+func Wrap(err error, ohnoer ohnoer.OhNoer, message string) error {
+	return wrap(err, ohnoer, message, sourceinfo.DefaultCallDepth+1)
+}
+
+// Wrapf is [Wrap] with the message formatted according to format as
+// [fmt.Sprintf] does.
+func Wrapf(err error, ohnoer ohnoer.OhNoer, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	return wrap(err, ohnoer, fmt.Sprintf(format, args...), sourceinfo.DefaultCallDepth+1)
+}
+
+// wrap implements [Wrap], callDepth is the same as the one of [New].
+func wrap(err error, ohnoer ohnoer.OhNoer, message string, callDepth int) error {
+	if err == nil {
+		return nil
+	}
+
+	if o, ok := err.(*OhNoError); ok && o != nil && sameCode(o.ErrorCode, ohnoer) {
+		merged := *o
+		merged.Fields = append(Fields(nil), o.Fields...)
+		if message != "" && merged.Message != "" {
+			merged.Message = message + commaSeparator + merged.Message
+		} else if message != "" {
+			merged.Message = message
+		}
+		if si := sourceinfo.GetSourceInformation(callDepth+1, GetConfig().SourceInfoType); si != nil {
+			merged.SourceInfo = si
+		}
+		return &merged
+	}
+
+	o := &OhNoError{ErrorCode: ohnoer, Message: message, Cause: err}
+	if sameCode(err, ohnoer) {
+		o.Cause = nil
+	}
+	return GetConfig().complete(o, sourceinfo.NoSourceInfo, callDepth+1)
+}
+
+// sameCode reports whether err is the error code ohnoer. Unlike ==, it does
+// not panic for error codes of types which are not comparable, those are
+// never the same.
+func sameCode(err error, ohnoer ohnoer.OhNoer) bool {
+	t := reflect.TypeOf(err)
+	return t != nil && t == reflect.TypeOf(ohnoer) && t.Comparable() && err == error(ohnoer)
+}
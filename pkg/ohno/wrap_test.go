@@ -0,0 +1,140 @@
+// Copyright © A.O.S, 2023.
+// All Rights Reserved.
+//
+// author: A.O.S
+
+package ohno_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/A-0-5/ohno/pkg/ohno"
+	"github.com/A-0-5/ohno/pkg/ohnoer"
+	"github.com/A-0-5/ohno/pkg/sourceinfo"
+)
+
+func TestWrap(t *testing.T) {
+	base := errors.New("open config.yaml: no such file or directory")
+	coded := ohno.Wrap(base, notFound, "opening the config")
+	uncomparable := uncomparableCode{"Broken"}
+
+	tests := []struct {
+		name    string
+		err     error
+		code    ohnoer.OhNoer
+		want    error // code of the result, nil if it has none
+		message string
+		cause   error // cause of the result
+	}{
+		{
+			name:    "plain error",
+			err:     base,
+			code:    notFound,
+			want:    notFound,
+			message: "starting",
+			cause:   base,
+		},
+		{
+			name:    "same code",
+			err:     coded,
+			code:    notFound,
+			want:    notFound,
+			message: "starting, opening the config",
+			cause:   base,
+		},
+		{
+			name:    "other code",
+			err:     coded,
+			code:    internal,
+			want:    internal,
+			message: "starting",
+			cause:   coded,
+		},
+		{
+			name:    "the code itself",
+			err:     notFound,
+			code:    notFound,
+			want:    notFound,
+			message: "starting",
+		},
+		{
+			name:    "same code under another code",
+			err:     ohno.Wrap(coded, internal, "loading"),
+			code:    notFound,
+			want:    notFound,
+			message: "starting",
+			cause:   ohno.Wrap(coded, internal, "loading"),
+		},
+		{
+			name:    "same code under another error",
+			err:     fmt.Errorf("loading: %w", coded),
+			code:    notFound,
+			want:    notFound,
+			message: "starting",
+			cause:   fmt.Errorf("loading: %w", coded),
+		},
+		{
+			name:    "uncomparable code",
+			err:     ohno.Wrap(base, uncomparable, "opening the config"),
+			code:    uncomparable,
+			want:    uncomparable,
+			message: "starting",
+			cause:   ohno.Wrap(base, uncomparable, "opening the config"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ohno.Wrap(tt.err, tt.code, "starting")
+
+			var o *ohno.OhNoError
+			if !errors.As(err, &o) {
+				t.Fatalf("%T is not an OhNoError", err)
+			}
+			if fmt.Sprint(o.ErrorCode) != fmt.Sprint(tt.want) {
+				t.Errorf("code %v, want %v", o.ErrorCode, tt.want)
+			}
+			if o.Message != tt.message {
+				t.Errorf("message %q, want %q", o.Message, tt.message)
+			}
+			if fmt.Sprint(o.Cause) != fmt.Sprint(tt.cause) {
+				t.Errorf("cause %v, want %v", o.Cause, tt.cause)
+			}
+			if _, ok := tt.code.(code); ok && !errors.Is(err, tt.code) {
+				t.Errorf("%v is not %v", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestWrapSourceInfo(t *testing.T) {
+	defer ohno.SetConfig(ohno.GetConfig())
+	base := errors.New("open config.yaml: no such file or directory")
+
+	ohno.SetConfig(ohno.Config{SourceInfoType: sourceinfo.ShortFileAndLine})
+	coded := ohno.Wrap(base, notFound, "opening the config").(*ohno.OhNoError)
+	merged := ohno.Wrap(coded, notFound, "starting").(*ohno.OhNoError)
+	if merged.SourceInfo == nil || coded.SourceInfo == nil || merged.SourceInfo.Line == coded.SourceInfo.Line {
+		t.Errorf("merged error at %v, want the line calling Wrap rather than %v", merged.SourceInfo, coded.SourceInfo)
+	}
+	if merged.SourceInfo != nil && merged.SourceInfo.File != "wrap_test.go" {
+		t.Errorf("merged error in %s, want wrap_test.go", merged.SourceInfo.File)
+	}
+
+	ohno.SetConfig(ohno.Config{})
+	kept := ohno.Wrap(coded, notFound, "starting").(*ohno.OhNoError)
+	if kept.SourceInfo != coded.SourceInfo {
+		t.Errorf("merged error at %v without source information configured, want %v", kept.SourceInfo, coded.SourceInfo)
+	}
+}
+
+func TestWrapNil(t *testing.T) {
+	if err := ohno.Wrap(nil, notFound, "starting"); err != nil {
+		t.Errorf("Wrap(nil) = %v, want nil", err)
+	}
+	if err := ohno.Wrapf(nil, notFound, "starting %s", "the server"); err != nil {
+		t.Errorf("Wrapf(nil) = %v, want nil", err)
+	}
+}